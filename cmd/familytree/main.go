@@ -21,7 +21,8 @@ func main() {
 
 	
 	flag.StringVar(&cfg.Country, "country", cfg.Country, "Country slug for demographics (e.g., 'united-states', 'japan')")
-	flag.IntVar(&cfg.Generations, "generations", cfg.Generations, "Number of generations to generate (1-10)")
+	flag.IntVar(&cfg.AncestorGenerations, "ancestors", cfg.AncestorGenerations, "Number of ancestor generations above the root (0-10, 0-15 with the early-modern profile)")
+	flag.IntVar(&cfg.DescendantGenerations, "descendants", cfg.DescendantGenerations, "Number of descendant generations below the root (0-8)")
 	flag.Int64Var(&cfg.Seed, "seed", cfg.Seed, "Random seed for reproducibility (0 = random)")
 	flag.StringVar(&cfg.OutputPath, "output", cfg.OutputPath, "Output file path")
 	flag.StringVar(&cfg.OutputFormat, "format", cfg.OutputFormat, "Output format: csv, json, or both")
@@ -29,8 +30,16 @@ func main() {
 	flag.BoolVar(&cfg.ListCountries, "list-countries", cfg.ListCountries, "List available countries and exit")
 	flag.IntVar(&cfg.StartYear, "start-year", cfg.StartYear, "Birth year of the root person")
 	flag.StringVar(&cfg.RootGender, "gender", cfg.RootGender, "Root person gender: M, F, or random")
+	flag.StringVar(&cfg.Region, "region", cfg.Region, "Name region within the country (e.g., 'Scotland'); empty picks one")
+	flag.Float64Var(&cfg.HostNameShare, "host-name-share", cfg.HostNameShare, "Share of given names drawn from the host country for children born abroad (0-1)")
 	flag.BoolVar(&cfg.IncludeExtended, "extended", cfg.IncludeExtended, "Include extended family (siblings)")
-	flag.StringVar(&cfg.LifeExpectancyMode, "life-expectancy", cfg.LifeExpectancyMode, "Life expectancy mode: total, female, male, or by_gender")
+	flag.IntVar(&cfg.CollateralDepth, "collateral-depth", cfg.CollateralDepth, "Cousin degree to which collateral lines get families (0-4, 0 = bare siblings, 2 = up to second cousins)")
+	flag.IntVar(&cfg.MaxPersons, "max-persons", cfg.MaxPersons, "Person budget for collateral lines")
+	flag.StringVar(&cfg.LifeExpectancyMode, "life-expectancy", cfg.LifeExpectancyMode, "Life expectancy mode: by_gender (sex-specific), or total, female or male to use one value for everyone")
+	flag.IntVar(&cfg.ProjectUntil, "project-until", cfg.ProjectUntil, "Project births, unions and deaths forward to this year (0 = off, max 2150)")
+	flag.StringVar(&cfg.Scenario, "scenario", cfg.Scenario, "Projection scenario for fertility and mortality: low, medium, or high")
+	flag.StringVar(&cfg.Profile, "profile", cfg.Profile, "Demographic profile before 1900: modern or early-modern (allows start years from 1500)")
+	flag.StringVar(&cfg.AsOf, "as-of", cfg.AsOf, "Date the tree is observed at, YYYY-MM-DD or YYYY (default today)")
 	flag.BoolVar(&cfg.Verbose, "verbose", cfg.Verbose, "Enable verbose output")
 
 	
@@ -40,8 +49,12 @@ func main() {
 		fmt.Fprintf(os.Stderr, "Options:\n")
 		flag.PrintDefaults()
 		fmt.Fprintf(os.Stderr, "\nExamples:\n")
-		fmt.Fprintf(os.Stderr, "  %s -country japan -generations 5 -seed 12345\n", os.Args[0])
+		fmt.Fprintf(os.Stderr, "  %s -country japan -ancestors 4 -descendants 0 -seed 12345\n", os.Args[0])
 		fmt.Fprintf(os.Stderr, "  %s -country germany -format json -output tree.json\n", os.Args[0])
+		fmt.Fprintf(os.Stderr, "  %s -country italy -ancestors 3 -collateral-depth 2\n", os.Args[0])
+		fmt.Fprintf(os.Stderr, "  %s -country france -start-year 1900 -as-of 1990-06-30\n", os.Args[0])
+		fmt.Fprintf(os.Stderr, "  %s -country japan -start-year 2010 -descendants 4 -project-until 2150 -scenario low\n", os.Args[0])
+		fmt.Fprintf(os.Stderr, "  %s -country germany -start-year 1880 -ancestors 14 -profile early-modern -format json\n", os.Args[0])
 		fmt.Fprintf(os.Stderr, "  %s -list-countries\n", os.Args[0])
 	}
 
@@ -88,11 +101,27 @@ func main() {
 
 	if cfg.Verbose {
 		fmt.Printf("Generating family tree for %s...\n", cfg.Country)
-		fmt.Printf("  Generations: %d\n", cfg.Generations)
+		fmt.Printf("  Ancestor generations: %d\n", cfg.AncestorGenerations)
+		fmt.Printf("  Descendant generations: %d\n", cfg.DescendantGenerations)
 		fmt.Printf("  Start year: %d\n", cfg.StartYear)
 		fmt.Printf("  Seed: %d\n", cfg.Seed)
+		if cfg.Region != "" {
+			fmt.Printf("  Region: %s\n", cfg.Region)
+		}
 		fmt.Printf("  Extended family: %v\n", cfg.IncludeExtended)
+		if cfg.CollateralDepth > 0 {
+			fmt.Printf("  Collateral depth: %d (max %d persons)\n", cfg.CollateralDepth, cfg.MaxPersons)
+		}
 		fmt.Printf("  Life expectancy: %s\n", cfg.LifeExpectancyMode)
+		if cfg.AsOf != "" {
+			fmt.Printf("  As of: %s\n", cfg.AsOf)
+		}
+		if cfg.Profile != string(data.ProfileModern) {
+			fmt.Printf("  Demographic profile: %s\n", cfg.Profile)
+		}
+		if cfg.ProjectUntil > 0 {
+			fmt.Printf("  Projection: until %d (%s scenario)\n", cfg.ProjectUntil, cfg.Scenario)
+		}
 	}
 
 	
@@ -134,8 +163,16 @@ func listCountries(repo *data.Repository) {
 	}
 	fmt.Println()
 
+	fmt.Printf("\nCountries with regional name data:\n\n")
+	for _, slug := range countries {
+		if regions := repo.GetNameRegions(slug); len(regions) > 0 {
+			fmt.Printf("  %-35s %s\n", slug, strings.Join(regions, ", "))
+		}
+	}
+
 	fmt.Printf("\nNote: Use the slug (lowercase with dashes) with the -country flag.\n")
 	fmt.Printf("Example: familytree -country united-states\n")
+	fmt.Printf("Example: familytree -country united-kingdom -region Scotland\n")
 }
 
 func writeOutput(tree *model.FamilyTree, cfg *config.AppConfig) error {
@@ -26,14 +26,21 @@ func main() {
 		fmt.Fprintf(os.Stderr, "  GET  /api/countries       - List available countries\n")
 		fmt.Fprintf(os.Stderr, "  GET  /api/country/{slug}  - Get country statistics\n")
 		fmt.Fprintf(os.Stderr, "  POST /api/generate        - Generate a family tree\n")
+		fmt.Fprintf(os.Stderr, "  GET  /api/tree/{id}/snapshot?date=YYYY-MM-DD - State of a generated tree at a date\n")
 		fmt.Fprintf(os.Stderr, "\nGenerate Request Body (JSON):\n")
 		fmt.Fprintf(os.Stderr, "  {\n")
 		fmt.Fprintf(os.Stderr, "    \"country\": \"germany\",\n")
-		fmt.Fprintf(os.Stderr, "    \"generations\": 3,\n")
+		fmt.Fprintf(os.Stderr, "    \"ancestor_generations\": 2,\n")
+		fmt.Fprintf(os.Stderr, "    \"descendant_generations\": 2,\n")
 		fmt.Fprintf(os.Stderr, "    \"seed\": 12345,\n")
 		fmt.Fprintf(os.Stderr, "    \"start_year\": 1970,\n")
 		fmt.Fprintf(os.Stderr, "    \"gender\": \"M\" or \"F\",\n")
-		fmt.Fprintf(os.Stderr, "    \"include_extended\": false\n")
+		fmt.Fprintf(os.Stderr, "    \"include_extended\": false,\n")
+		fmt.Fprintf(os.Stderr, "    \"collateral_depth\": 0,\n")
+		fmt.Fprintf(os.Stderr, "    \"as_of\": \"2024-12-31\",\n")
+		fmt.Fprintf(os.Stderr, "    \"project_until\": 2150,\n")
+		fmt.Fprintf(os.Stderr, "    \"scenario\": \"low\", \"medium\" or \"high\",\n")
+		fmt.Fprintf(os.Stderr, "    \"profile\": \"modern\" or \"early-modern\"\n")
 		fmt.Fprintf(os.Stderr, "  }\n")
 	}
 
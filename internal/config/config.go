@@ -1,18 +1,31 @@
 package config
 
 import (
+	"fmt"
+	"time"
+
+	"github.com/familytree-generator/internal/data"
 	"github.com/familytree-generator/internal/generator"
 	"github.com/familytree-generator/internal/model"
 )
 
 type AppConfig struct {
-	Country            string
-	Generations        int
-	Seed               int64
-	StartYear          int
-	RootGender         string
-	IncludeExtended    bool
-	LifeExpectancyMode string
+	Country               string
+	AncestorGenerations   int
+	DescendantGenerations int
+	Seed                  int64
+	StartYear             int
+	RootGender            string
+	Region                string
+	HostNameShare         float64
+	IncludeExtended       bool
+	CollateralDepth       int
+	MaxPersons            int
+	LifeExpectancyMode    string
+	AsOf                  string
+	Scenario              string
+	ProjectUntil          int
+	Profile               string
 
 	OutputPath   string
 	OutputFormat string
@@ -21,22 +34,31 @@ type AppConfig struct {
 
 	ListCountries bool
 	Verbose       bool
+
+	asOf time.Time
 }
 
 func DefaultAppConfig() *AppConfig {
 	return &AppConfig{
-		Country:            "germany",
-		Generations:        3,
-		Seed:               0,
-		StartYear:          1970,
-		RootGender:         "random",
-		IncludeExtended:    false,
-		LifeExpectancyMode: string(generator.LifeExpectancyTotal),
-		OutputPath:         "family_tree.csv",
-		OutputFormat:       "csv",
-		DataDir:            "./data",
-		ListCountries:      false,
-		Verbose:            false,
+		Country:               "germany",
+		AncestorGenerations:   2,
+		DescendantGenerations: 2,
+		Seed:                  0,
+		StartYear:             1970,
+		RootGender:            "random",
+		HostNameShare:         0.5,
+		IncludeExtended:       false,
+		CollateralDepth:       0,
+		MaxPersons:            2000,
+		LifeExpectancyMode:    string(generator.LifeExpectancyByGender),
+		Scenario:              string(data.ScenarioMedium),
+		ProjectUntil:          0,
+		Profile:               string(data.ProfileModern),
+		OutputPath:            "family_tree.csv",
+		OutputFormat:          "csv",
+		DataDir:               "./data",
+		ListCountries:         false,
+		Verbose:               false,
 	}
 }
 
@@ -52,32 +74,83 @@ func (c *AppConfig) ToGeneratorConfig() generator.Config {
 	}
 
 	return generator.Config{
-		Country:            c.Country,
-		Generations:        c.Generations,
-		Seed:               c.Seed,
-		StartYear:          c.StartYear,
-		RootGender:         gender,
-		IncludeExtended:    c.IncludeExtended,
-		LifeExpectancyMode: generator.ParseLifeExpectancyMode(c.LifeExpectancyMode),
+		Country:               c.Country,
+		AncestorGenerations:   c.AncestorGenerations,
+		DescendantGenerations: c.DescendantGenerations,
+		Seed:                  c.Seed,
+		StartYear:             c.StartYear,
+		RootGender:            gender,
+		Region:                c.Region,
+		HostNameShare:         c.HostNameShare,
+		IncludeExtended:       c.IncludeExtended,
+		CollateralDepth:       c.CollateralDepth,
+		MaxPersons:            c.MaxPersons,
+		LifeExpectancyMode:    generator.ParseLifeExpectancyMode(c.LifeExpectancyMode),
+		AsOf:                  c.asOf,
+		Scenario:              data.ParseScenario(c.Scenario),
+		ProjectUntil:          c.ProjectUntil,
+		Profile:               data.ParseProfile(c.Profile),
 	}
 }
 
 func (c *AppConfig) Validate() error {
-	if c.Generations < 1 {
-		c.Generations = 1
+	c.Profile = string(data.ParseProfile(c.Profile))
+	maxAncestors, minStartYear := generator.ProfileLimits(data.Profile(c.Profile))
+
+	if c.AncestorGenerations < 0 {
+		c.AncestorGenerations = 0
+	}
+	if c.AncestorGenerations > maxAncestors {
+		c.AncestorGenerations = maxAncestors
+	}
+
+	if c.DescendantGenerations < 0 {
+		c.DescendantGenerations = 0
 	}
-	if c.Generations > 10 {
-		c.Generations = 10
+	if c.DescendantGenerations > 8 {
+		c.DescendantGenerations = 8
 	}
 
-	if c.StartYear < 1800 {
-		c.StartYear = 1800
+	if c.StartYear < minStartYear {
+		c.StartYear = minStartYear
 	}
-	if c.StartYear > 2024 {
-		c.StartYear = 2024
+	if c.StartYear > 2150 {
+		c.StartYear = 2150
+	}
+
+	if c.ProjectUntil < 0 {
+		c.ProjectUntil = 0
+	}
+	if c.ProjectUntil > 2150 {
+		c.ProjectUntil = 2150
+	}
+
+	if c.CollateralDepth < 0 {
+		c.CollateralDepth = 0
+	}
+	if c.CollateralDepth > generator.MaxCollateralDepth {
+		c.CollateralDepth = generator.MaxCollateralDepth
+	}
+
+	if c.MaxPersons < 1 {
+		c.MaxPersons = 2000
+	}
+
+	if c.HostNameShare < 0 {
+		c.HostNameShare = 0
+	}
+	if c.HostNameShare > 1 {
+		c.HostNameShare = 1
 	}
 
 	c.LifeExpectancyMode = string(generator.ParseLifeExpectancyMode(c.LifeExpectancyMode))
+	c.Scenario = string(data.ParseScenario(c.Scenario))
+
+	asOf, err := generator.ParseAsOf(c.AsOf)
+	if err != nil {
+		return fmt.Errorf("invalid as-of date: %w", err)
+	}
+	c.asOf = asOf
 
 	return nil
 }
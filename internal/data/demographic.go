@@ -14,6 +14,7 @@ type DemographicData struct {
 	MigrationRates       map[string]float64
 	InfantMortality      map[string]float64
 	Population           map[string]float64
+	Regions              map[string]string
 }
 
 func LoadDemographicData(dataDir string) (*DemographicData, error) {
@@ -53,6 +54,7 @@ func LoadDemographicData(dataDir string) (*DemographicData, error) {
 		return nil, fmt.Errorf("loading population.csv: %w", err)
 	}
 	d.Population = RecordsToMap(records)
+	d.Regions = RecordsToRegionMap(records)
 
 	return d, nil
 }
@@ -155,3 +157,7 @@ func (d *DemographicData) GetPopulation(slug string) float64 {
 	}
 	return 0
 }
+
+func (d *DemographicData) GetRegion(slug string) string {
+	return d.Regions[slug]
+}
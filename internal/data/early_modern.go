@@ -0,0 +1,254 @@
+package data
+
+import "math"
+
+type Profile string
+
+const (
+	ProfileModern      Profile = "modern"
+	ProfileEarlyModern Profile = "early-modern"
+)
+
+const (
+	EarlyModernFrom      = 1500
+	EarlyModernUntil     = 1900
+	EarlyModernBlendFrom = 1850
+
+	marriageAgeSpread = 4.0
+)
+
+type EarlyModernRegime struct {
+	Pattern               string
+	WomenMarriageAge      float64
+	MarriageRate          float64
+	TotalFertility        float64
+	YouthMortality        float64
+	BirthsOutsideMarriage float64
+	SingleParentShare     float64
+	NameGroup             string
+}
+
+var (
+	northWestEuropean = EarlyModernRegime{
+		Pattern:               "north-west european",
+		WomenMarriageAge:      25.5,
+		MarriageRate:          7.5,
+		TotalFertility:        4.8,
+		YouthMortality:        40,
+		BirthsOutsideMarriage: 3,
+		SingleParentShare:     2.5,
+	}
+	mediterranean = EarlyModernRegime{
+		Pattern:               "mediterranean",
+		WomenMarriageAge:      23.5,
+		MarriageRate:          8.5,
+		TotalFertility:        5.2,
+		YouthMortality:        45,
+		BirthsOutsideMarriage: 3,
+		SingleParentShare:     2.5,
+	}
+	settlerColonial = EarlyModernRegime{
+		Pattern:               "settler colonial",
+		WomenMarriageAge:      21.5,
+		MarriageRate:          9.5,
+		TotalFertility:        6.5,
+		YouthMortality:        30,
+		BirthsOutsideMarriage: 2,
+		SingleParentShare:     1.5,
+	}
+	universalMarriage = EarlyModernRegime{
+		Pattern:               "early universal marriage",
+		WomenMarriageAge:      19.5,
+		MarriageRate:          10,
+		TotalFertility:        6.0,
+		YouthMortality:        45,
+		BirthsOutsideMarriage: 2,
+		SingleParentShare:     1.5,
+	}
+)
+
+var earlyModernRegimes = map[string]EarlyModernRegime{
+	"united-kingdom": northWestEuropean,
+	"ireland":        northWestEuropean,
+	"france":         northWestEuropean,
+	"belgium":        northWestEuropean,
+	"netherlands":    northWestEuropean,
+	"luxembourg":     northWestEuropean,
+	"germany":        northWestEuropean,
+	"austria":        northWestEuropean,
+	"switzerland":    northWestEuropean,
+	"czechia":        northWestEuropean,
+	"denmark":        northWestEuropean,
+	"norway":         northWestEuropean,
+	"sweden":         northWestEuropean,
+	"finland":        northWestEuropean,
+	"iceland":        northWestEuropean,
+	"faroe-islands":  northWestEuropean,
+	"italy":          mediterranean,
+	"spain":          mediterranean,
+	"portugal":       mediterranean,
+	"greece":         mediterranean,
+	"malta":          mediterranean,
+	"cyprus":         mediterranean,
+	"united-states":  settlerColonial,
+	"canada":         settlerColonial,
+	"australia":      settlerColonial,
+	"new-zealand":    settlerColonial,
+}
+
+var naturalMaritalFertility = []float64{0.411, 0.460, 0.431, 0.396, 0.321, 0.167, 0.024}
+
+var parishNameGroups = map[string]string{
+	"united-kingdom": "english",
+	"ireland":        "english",
+	"united-states":  "english",
+	"canada":         "english",
+	"australia":      "english",
+	"new-zealand":    "english",
+	"germany":        "german",
+	"austria":        "german",
+	"switzerland":    "german",
+	"luxembourg":     "german",
+	"netherlands":    "dutch",
+	"belgium":        "dutch",
+	"france":         "french",
+	"italy":          "italian",
+	"malta":          "italian",
+	"spain":          "spanish",
+	"mexico":         "spanish",
+	"argentina":      "spanish",
+	"chile":          "spanish",
+	"colombia":       "spanish",
+	"peru":           "spanish",
+	"portugal":       "portuguese",
+	"brazil":         "portuguese",
+	"sweden":         "swedish",
+	"finland":        "swedish",
+	"denmark":        "danish",
+	"norway":         "danish",
+	"iceland":        "icelandic",
+	"poland":         "polish",
+	"czechia":        "czech",
+	"slovakia":       "czech",
+	"hungary":        "hungarian",
+}
+
+var parishForenames = map[string]map[string][]string{
+	"english": {
+		"M": {"John", "William", "Thomas", "Richard", "James", "Robert", "Henry", "George", "Edward", "Joseph", "Samuel", "Francis", "Charles", "Nicholas", "Edmund"},
+		"F": {"Mary", "Elizabeth", "Anne", "Sarah", "Margaret", "Jane", "Alice", "Joan", "Catherine", "Hannah", "Agnes", "Martha", "Susanna", "Ellen", "Frances"},
+	},
+	"german": {
+		"M": {"Johann", "Hans", "Georg", "Michael", "Jakob", "Peter", "Andreas", "Christoph", "Heinrich", "Matthias", "Martin", "Friedrich", "Caspar", "Nikolaus", "Adam"},
+		"F": {"Anna", "Maria", "Catharina", "Margaretha", "Barbara", "Elisabeth", "Magdalena", "Eva", "Dorothea", "Christina", "Ursula", "Sophia", "Susanna", "Regina", "Agnes"},
+	},
+	"dutch": {
+		"M": {"Jan", "Pieter", "Cornelis", "Hendrik", "Willem", "Jacob", "Dirk", "Gerrit", "Claes", "Adriaen", "Johannes", "Arent", "Teunis", "Joost", "Maarten"},
+		"F": {"Maria", "Anna", "Johanna", "Cornelia", "Neeltje", "Grietje", "Geertruida", "Catharina", "Elisabeth", "Aaltje", "Trijntje", "Adriana", "Jannetje", "Hendrikje", "Marritje"},
+	},
+	"french": {
+		"M": {"Jean", "Pierre", "Jacques", "François", "Nicolas", "Louis", "Antoine", "Claude", "Joseph", "Étienne", "Guillaume", "Charles", "Michel", "Denis", "Mathieu"},
+		"F": {"Marie", "Jeanne", "Anne", "Marguerite", "Catherine", "Françoise", "Madeleine", "Louise", "Élisabeth", "Antoinette", "Claudine", "Geneviève", "Nicole", "Perrine", "Barbe"},
+	},
+	"italian": {
+		"M": {"Giovanni", "Giuseppe", "Antonio", "Francesco", "Domenico", "Pietro", "Giacomo", "Bartolomeo", "Andrea", "Carlo", "Lorenzo", "Michele", "Paolo", "Angelo", "Battista"},
+		"F": {"Maria", "Caterina", "Margherita", "Lucia", "Domenica", "Francesca", "Giovanna", "Anna", "Angela", "Teresa", "Elisabetta", "Maddalena", "Antonia", "Giacoma", "Orsola"},
+	},
+	"spanish": {
+		"M": {"Juan", "José", "Francisco", "Pedro", "Antonio", "Manuel", "Diego", "Alonso", "Miguel", "Andrés", "Martín", "Bartolomé", "Domingo", "Fernando", "Sebastián"},
+		"F": {"María", "Juana", "Ana", "Catalina", "Isabel", "Francisca", "Josefa", "Antonia", "Inés", "Manuela", "Teresa", "Magdalena", "Luisa", "Beatriz", "Leonor"},
+	},
+	"portuguese": {
+		"M": {"João", "Manuel", "António", "José", "Francisco", "Pedro", "Domingos", "Joaquim", "Luís", "Bento", "Gonçalo", "Miguel", "Bartolomeu", "Sebastião", "Jerónimo"},
+		"F": {"Maria", "Ana", "Joana", "Catarina", "Isabel", "Francisca", "Teresa", "Josefa", "Luísa", "Antónia", "Inês", "Brites", "Margarida", "Rosa", "Mariana"},
+	},
+	"swedish": {
+		"M": {"Johan", "Anders", "Per", "Erik", "Lars", "Nils", "Olof", "Carl", "Jöns", "Mats", "Jon", "Sven", "Gustaf", "Måns", "Jakob"},
+		"F": {"Maria", "Anna", "Kerstin", "Brita", "Karin", "Catharina", "Margareta", "Christina", "Ingrid", "Elin", "Stina", "Kajsa", "Maja", "Greta", "Elsa"},
+	},
+	"danish": {
+		"M": {"Hans", "Jens", "Niels", "Peder", "Christen", "Rasmus", "Anders", "Søren", "Ole", "Lars", "Jørgen", "Mads", "Poul", "Christian", "Knud"},
+		"F": {"Anna", "Maren", "Karen", "Kirsten", "Johanne", "Ane", "Else", "Mette", "Inger", "Bodil", "Birthe", "Dorthe", "Margrethe", "Sidsel", "Kirstine"},
+	},
+	"icelandic": {
+		"M": {"Jón", "Guðmundur", "Sigurður", "Ólafur", "Magnús", "Einar", "Bjarni", "Þorsteinn", "Árni", "Páll", "Gísli", "Þórður", "Björn", "Halldór", "Helgi"},
+		"F": {"Guðrún", "Sigríður", "Kristín", "Margrét", "Helga", "Ingibjörg", "Valgerður", "Þórunn", "Ragnheiður", "Guðný", "Halldóra", "Steinunn", "Sesselja", "Ólöf", "Solveig"},
+	},
+	"polish": {
+		"M": {"Jan", "Wojciech", "Józef", "Stanisław", "Jakub", "Maciej", "Andrzej", "Marcin", "Szymon", "Tomasz", "Mateusz", "Piotr", "Kazimierz", "Walenty", "Franciszek"},
+		"F": {"Marianna", "Katarzyna", "Agnieszka", "Zofia", "Jadwiga", "Barbara", "Regina", "Anna", "Magdalena", "Rozalia", "Franciszka", "Małgorzata", "Dorota", "Ewa", "Józefa"},
+	},
+	"czech": {
+		"M": {"Jan", "Josef", "Václav", "Jiří", "Matěj", "Jakub", "František", "Martin", "Tomáš", "Pavel", "Vojtěch", "Mikuláš", "Antonín", "Jindřich", "Adam"},
+		"F": {"Anna", "Marie", "Kateřina", "Dorota", "Magdalena", "Alžběta", "Ludmila", "Johana", "Barbora", "Veronika", "Markéta", "Terezie", "Rozina", "Eva", "Voršila"},
+	},
+	"hungarian": {
+		"M": {"János", "István", "György", "Mihály", "József", "Ferenc", "András", "Péter", "Pál", "Mátyás", "Gergely", "Imre", "Márton", "Lukács", "Tamás"},
+		"F": {"Erzsébet", "Katalin", "Anna", "Mária", "Judit", "Ilona", "Zsuzsanna", "Borbála", "Éva", "Margit", "Julianna", "Sára", "Dorottya", "Orsolya", "Rozália"},
+	},
+}
+
+func ParseProfile(value string) Profile {
+	if Profile(value) == ProfileEarlyModern {
+		return ProfileEarlyModern
+	}
+	return ProfileModern
+}
+
+func (r *Repository) WithProfile(profile Profile) *Repository {
+	profiled := *r
+	profiled.profile = ParseProfile(string(profile))
+	return &profiled
+}
+
+func (r *Repository) Profile() Profile {
+	return r.profile
+}
+
+func (r *Repository) InEarlyModernProfile(year int) bool {
+	return r.profile == ProfileEarlyModern && year < EarlyModernUntil
+}
+
+func (r *Repository) EarlyModernWeight(year int) float64 {
+	if !r.InEarlyModernProfile(year) {
+		return 0
+	}
+	if year <= EarlyModernBlendFrom {
+		return 1
+	}
+	return float64(EarlyModernUntil-year) / float64(EarlyModernUntil-EarlyModernBlendFrom)
+}
+
+func (r *Repository) GetEarlyModernRegime(slug string) EarlyModernRegime {
+	regime, ok := earlyModernRegimes[slug]
+	if !ok {
+		regime = universalMarriage
+	}
+	regime.NameGroup = parishNameGroups[slug]
+	return regime
+}
+
+func (r *Repository) GetParishForenames(slug, gender string) []string {
+	return parishForenames[parishNameGroups[slug]][gender]
+}
+
+func (r *Repository) blendEarlyModern(slug string, year int, modern float64, early func(EarlyModernRegime) float64) float64 {
+	weight := r.EarlyModernWeight(year)
+	if weight == 0 {
+		return modern
+	}
+	return weight*early(r.GetEarlyModernRegime(slug)) + (1-weight)*modern
+}
+
+func (r *Repository) earlyModernFertilitySchedule(slug string) ([]int, []float64) {
+	womenAge := r.GetEarlyModernRegime(slug).WomenMarriageAge
+
+	ages := make([]int, 0, 35)
+	rates := make([]float64, 0, 35)
+	for age := 15; age < 50; age++ {
+		married := 0.5 * (1 + math.Erf((float64(age)+0.5-womenAge)/(marriageAgeSpread*math.Sqrt2)))
+		ages = append(ages, age)
+		rates = append(rates, naturalMaritalFertility[(age-15)/5]*married)
+	}
+	return ages, rates
+}
@@ -19,10 +19,11 @@ type HistoricalRecord struct {
 }
 
 type HistoricalDataset struct {
-	Name    string
-	Records []HistoricalRecord
-	ByCode  map[string][]HistoricalRecord
-	ByYear  map[int][]HistoricalRecord
+	Name       string
+	Records    []HistoricalRecord
+	ByCode     map[string][]HistoricalRecord
+	ByYear     map[int][]HistoricalRecord
+	Projection Projection
 }
 
 type HistoricalData struct {
@@ -34,9 +35,37 @@ type HistoricalData struct {
 	MarriageRate          *HistoricalDataset
 	SingleParentShare     *HistoricalDataset
 	UrbanPopulationShare  *HistoricalDataset
+	SameSexMarriage       *LegalStatusDataset
+	MotherAgeFertility    *AgeScheduleDataset
+	HeightMen             *HistoricalDataset
+	HeightWomen           *HistoricalDataset
+	LiteracyRate          *HistoricalDataset
+	Emigrants             *HistoricalDataset
+	GDP                   *HistoricalDataset
+}
+
+type AgeScheduleDataset struct {
+	Name  string
+	ByAge map[int]*HistoricalDataset
+}
+
+type LegalStatusRecord struct {
+	Entity string
+	Code   string
+	Year   int
+	Status string
+}
+
+type LegalStatusDataset struct {
+	Name   string
+	ByCode map[string][]LegalStatusRecord
 }
 
 func LoadHistoricalCSV(filepath string) (*HistoricalDataset, error) {
+	return LoadHistoricalCSVColumn(filepath, 3)
+}
+
+func LoadHistoricalCSVColumn(filepath string, column int) (*HistoricalDataset, error) {
 	data, err := os.ReadFile(filepath)
 	if err != nil {
 		return nil, fmt.Errorf("reading file: %w", err)
@@ -64,7 +93,7 @@ func LoadHistoricalCSV(filepath string) (*HistoricalDataset, error) {
 	}
 
 	for _, row := range records[1:] {
-		if len(row) < 4 {
+		if len(row) <= column {
 			continue
 		}
 
@@ -73,7 +102,7 @@ func LoadHistoricalCSV(filepath string) (*HistoricalDataset, error) {
 			continue
 		}
 
-		value, err := strconv.ParseFloat(strings.TrimSpace(row[3]), 64)
+		value, err := strconv.ParseFloat(strings.TrimSpace(row[column]), 64)
 		if err != nil {
 			continue
 		}
@@ -170,6 +199,192 @@ func LoadUrbanShareCSV(filepath string) (*HistoricalDataset, error) {
 	return dataset, nil
 }
 
+func LoadLegalStatusCSV(filepath string) (*LegalStatusDataset, error) {
+	data, err := os.ReadFile(filepath)
+	if err != nil {
+		return nil, fmt.Errorf("reading file: %w", err)
+	}
+
+	data = bytes.TrimPrefix(data, []byte{0xEF, 0xBB, 0xBF})
+
+	reader := csv.NewReader(bytes.NewReader(data))
+	reader.TrimLeadingSpace = true
+	reader.FieldsPerRecord = -1
+
+	records, err := reader.ReadAll()
+	if err != nil {
+		return nil, fmt.Errorf("parsing CSV: %w", err)
+	}
+
+	if len(records) < 2 {
+		return nil, fmt.Errorf("no data rows in file")
+	}
+
+	dataset := &LegalStatusDataset{
+		Name:   filepath,
+		ByCode: make(map[string][]LegalStatusRecord),
+	}
+
+	for _, row := range records[1:] {
+		if len(row) < 4 {
+			continue
+		}
+
+		year, err := strconv.Atoi(strings.TrimSpace(row[2]))
+		if err != nil {
+			continue
+		}
+
+		record := LegalStatusRecord{
+			Entity: strings.TrimSpace(row[0]),
+			Code:   strings.TrimSpace(row[1]),
+			Year:   year,
+			Status: strings.TrimSpace(row[3]),
+		}
+		if record.Code == "" || record.Status == "" {
+			continue
+		}
+
+		dataset.ByCode[record.Code] = append(dataset.ByCode[record.Code], record)
+	}
+
+	for code := range dataset.ByCode {
+		sort.Slice(dataset.ByCode[code], func(i, j int) bool {
+			return dataset.ByCode[code][i].Year < dataset.ByCode[code][j].Year
+		})
+	}
+
+	return dataset, nil
+}
+
+func LoadAgeScheduleCSV(filepath string) (*AgeScheduleDataset, error) {
+	data, err := os.ReadFile(filepath)
+	if err != nil {
+		return nil, fmt.Errorf("reading file: %w", err)
+	}
+
+	data = bytes.TrimPrefix(data, []byte{0xEF, 0xBB, 0xBF})
+
+	reader := csv.NewReader(bytes.NewReader(data))
+	reader.TrimLeadingSpace = true
+	reader.FieldsPerRecord = -1
+
+	records, err := reader.ReadAll()
+	if err != nil {
+		return nil, fmt.Errorf("parsing CSV: %w", err)
+	}
+
+	if len(records) < 2 {
+		return nil, fmt.Errorf("no data rows in file")
+	}
+
+	header := records[0]
+	columnYears := make([]int, len(header))
+	for i := 3; i < len(header); i++ {
+		columnYears[i], _ = strconv.Atoi(strings.TrimSpace(header[i]))
+	}
+
+	dataset := &AgeScheduleDataset{
+		Name:  filepath,
+		ByAge: make(map[int]*HistoricalDataset),
+	}
+
+	for _, row := range records[1:] {
+		if len(row) < 4 {
+			continue
+		}
+
+		age, err := strconv.Atoi(strings.TrimSpace(row[2]))
+		if err != nil {
+			continue
+		}
+
+		schedule, ok := dataset.ByAge[age]
+		if !ok {
+			schedule = &HistoricalDataset{
+				Name:   fmt.Sprintf("%s (age %d)", filepath, age),
+				ByCode: make(map[string][]HistoricalRecord),
+				ByYear: make(map[int][]HistoricalRecord),
+			}
+			dataset.ByAge[age] = schedule
+		}
+
+		for i := 3; i < len(row) && i < len(columnYears); i++ {
+			if columnYears[i] == 0 {
+				continue
+			}
+			value, err := strconv.ParseFloat(strings.TrimSpace(row[i]), 64)
+			if err != nil {
+				continue
+			}
+
+			record := HistoricalRecord{
+				Entity: strings.TrimSpace(row[0]),
+				Code:   strings.TrimSpace(row[1]),
+				Year:   columnYears[i],
+				Value:  value,
+			}
+
+			schedule.Records = append(schedule.Records, record)
+			schedule.ByCode[record.Code] = append(schedule.ByCode[record.Code], record)
+			schedule.ByYear[record.Year] = append(schedule.ByYear[record.Year], record)
+		}
+	}
+
+	for _, schedule := range dataset.ByAge {
+		for code := range schedule.ByCode {
+			sort.Slice(schedule.ByCode[code], func(i, j int) bool {
+				return schedule.ByCode[code][i].Year < schedule.ByCode[code][j].Year
+			})
+		}
+	}
+
+	return dataset, nil
+}
+
+func (d *AgeScheduleDataset) GetAges() []int {
+	ages := make([]int, 0, len(d.ByAge))
+	for age := range d.ByAge {
+		ages = append(ages, age)
+	}
+	sort.Ints(ages)
+	return ages
+}
+
+func (d *AgeScheduleDataset) GetValue(code string, age, year int) (float64, bool) {
+	schedule, ok := d.ByAge[age]
+	if !ok {
+		return 0, false
+	}
+	return schedule.GetValue(code, year)
+}
+
+func (d *LegalStatusDataset) GetStatus(code string, year int) (string, bool) {
+	records, ok := d.ByCode[code]
+	if !ok || len(records) == 0 || year < records[0].Year {
+		return "", false
+	}
+
+	status := records[0].Status
+	for _, r := range records {
+		if r.Year > year {
+			break
+		}
+		status = r.Status
+	}
+	return status, true
+}
+
+func (d *HistoricalDataset) GetProjectedValue(code string, year int, scenario Scenario) (float64, bool) {
+	records := d.ByCode[code]
+	if scenario != "" && d.Projection != nil && len(records) > 0 {
+		if last := records[len(records)-1]; year > last.Year {
+			return d.Projection(last, year, scenario), true
+		}
+	}
+	return d.GetValue(code, year)
+}
+
 func (d *HistoricalDataset) GetValue(code string, year int) (float64, bool) {
 	records, ok := d.ByCode[code]
 	if !ok || len(records) == 0 {
@@ -217,6 +432,14 @@ func (d *HistoricalDataset) GetLatestValue(code string) (float64, int, bool) {
 	return latest.Value, latest.Year, true
 }
 
+func (d *HistoricalDataset) GetFirstYear(code string) (int, bool) {
+	records, ok := d.ByCode[code]
+	if !ok || len(records) == 0 {
+		return 0, false
+	}
+	return records[0].Year, true
+}
+
 func (d *HistoricalDataset) GetValueOrDefault(code string, year int, defaultVal float64) float64 {
 	if val, ok := d.GetValue(code, year); ok {
 		return val
@@ -258,6 +481,7 @@ func LoadHistoricalData(dataDir string) (*HistoricalData, error) {
 	if err != nil {
 		return nil, fmt.Errorf("loading fertility rate: %w", err)
 	}
+	h.FertilityRate.Projection = convergeProjection(fertilityTargets, fertilityConvergenceYears)
 
 	h.MarriageAgeWomen, err = LoadHistoricalCSV(filepath.Join(dataDir, "age-at-marriage-women.csv"))
 	if err != nil {
@@ -273,6 +497,7 @@ func LoadHistoricalData(dataDir string) (*HistoricalData, error) {
 	if err != nil {
 		return nil, fmt.Errorf("loading youth mortality: %w", err)
 	}
+	h.YouthMortality.Projection = declineProjection(youthMortalityDecline, youthMortalityFloor)
 
 	h.BirthsOutsideMarriage, err = LoadHistoricalCSV(filepath.Join(dataDir, "share-of-births-outside-marriage.csv"))
 	if err != nil {
@@ -294,6 +519,41 @@ func LoadHistoricalData(dataDir string) (*HistoricalData, error) {
 		return nil, fmt.Errorf("loading urban population share: %w", err)
 	}
 
+	h.SameSexMarriage, err = LoadLegalStatusCSV(filepath.Join(dataDir, "marriage-same-sex-partners-equaldex.csv"))
+	if err != nil {
+		return nil, fmt.Errorf("loading same-sex marriage status: %w", err)
+	}
+
+	h.MotherAgeFertility, err = LoadAgeScheduleCSV(filepath.Join(dataDir, "age-of-mothers-at-childbirth-by-year.csv"))
+	if err != nil {
+		return nil, fmt.Errorf("loading fertility by mother age: %w", err)
+	}
+
+	h.HeightMen, err = LoadHistoricalCSVColumn(filepath.Join(dataDir, "average-height-by-year-of-birth.csv"), 3)
+	if err != nil {
+		return nil, fmt.Errorf("loading average height of men: %w", err)
+	}
+
+	h.HeightWomen, err = LoadHistoricalCSVColumn(filepath.Join(dataDir, "average-height-by-year-of-birth.csv"), 4)
+	if err != nil {
+		return nil, fmt.Errorf("loading average height of women: %w", err)
+	}
+
+	h.LiteracyRate, err = LoadHistoricalCSV(filepath.Join(dataDir, "cross-country-literacy-rates.csv"))
+	if err != nil {
+		return nil, fmt.Errorf("loading literacy rate: %w", err)
+	}
+
+	h.Emigrants, err = LoadHistoricalCSV(filepath.Join(dataDir, "total-number-of-emigrants.csv"))
+	if err != nil {
+		return nil, fmt.Errorf("loading emigrants: %w", err)
+	}
+
+	h.GDP, err = LoadHistoricalCSV(filepath.Join(dataDir, "gdp.csv"))
+	if err != nil {
+		return nil, fmt.Errorf("loading gdp: %w", err)
+	}
+
 	return h, nil
 }
 
@@ -326,7 +586,8 @@ var slugToISO3 = map[string]string{
 	"ukraine": "UKR", "united-arab-emirates": "ARE", "united-kingdom": "GBR",
 	"united-states": "USA", "uruguay": "URY", "uzbekistan": "UZB",
 	"venezuela": "VEN", "vietnam": "VNM", "yemen": "YEM", "zambia": "ZMB",
-	"zimbabwe": "ZWE", "faroe-islands": "FRO",
+	"zimbabwe": "ZWE", "faroe-islands": "FRO", "turkey-turkiye": "TUR",
+	"korea-south": "KOR", "czechia": "CZE",
 }
 
 func GetISO3FromSlug(slug string) string {
@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"sort"
 	"strconv"
 	"strings"
 )
@@ -38,6 +39,17 @@ type IdentityData struct {
 	NameToCode map[string]string
 
 	SlugToCode map[string]string
+
+	LocalizedForenames map[string]map[string]string
+	LocalizedSurnames  map[string]map[string]string
+}
+
+var slugAliases = map[string]string{
+	"russia":          "RU",
+	"czechia":         "CZ",
+	"korea-south":     "KR",
+	"turkey-turkiye":  "TR",
+	"north-macedonia": "MK",
 }
 
 func LoadIdentityData(dataDir string) (*IdentityData, error) {
@@ -47,6 +59,9 @@ func LoadIdentityData(dataDir string) (*IdentityData, error) {
 		CountryCodes: make(map[string]string),
 		NameToCode:   make(map[string]string),
 		SlugToCode:   make(map[string]string),
+
+		LocalizedForenames: make(map[string]map[string]string),
+		LocalizedSurnames:  make(map[string]map[string]string),
 	}
 
 	if err := LoadJSON(filepath.Join(dataDir, "countries-code.json"), &id.CountryCodes); err != nil {
@@ -60,6 +75,9 @@ func LoadIdentityData(dataDir string) (*IdentityData, error) {
 		slug := toSlug(name)
 		id.SlugToCode[slug] = code
 	}
+	for slug, code := range slugAliases {
+		id.SlugToCode[slug] = code
+	}
 
 	if err := id.loadForenames(filepath.Join(dataDir, "forenames.csv")); err != nil {
 		return nil, fmt.Errorf("loading forenames.csv: %w", err)
@@ -111,6 +129,7 @@ func (id *IdentityData) loadForenames(filepath string) error {
 		}
 
 		id.Forenames[record.Country] = append(id.Forenames[record.Country], record)
+		addLocalizedName(id.LocalizedForenames, record.Country, record.RomanizedName, record.LocalizedName)
 	}
 
 	return nil
@@ -130,12 +149,14 @@ func (id *IdentityData) loadSurnames(filepath string) error {
 		return err
 	}
 
+	groupLocalized := make(map[string]string)
 	for _, row := range records[1:] {
 		if len(row) < 6 {
 			continue
 		}
 
 		rank, _ := strconv.Atoi(row[1])
+		group := strings.TrimSpace(row[3])
 
 		percentage, err := strconv.ParseFloat(strings.TrimSpace(row[7]), 64)
 		if err != nil {
@@ -161,11 +182,40 @@ func (id *IdentityData) loadSurnames(filepath string) error {
 		}
 
 		id.Surnames[record.Country] = append(id.Surnames[record.Country], record)
+
+		localized := record.LocalizedName
+		if localized == "" {
+			localized = groupLocalized[group]
+		} else if _, ok := groupLocalized[group]; !ok && group != "" {
+			groupLocalized[group] = localized
+		}
+		addLocalizedName(id.LocalizedSurnames, record.Country, record.RomanizedName, localized)
 	}
 
 	return nil
 }
 
+func addLocalizedName(index map[string]map[string]string, country, romanized, localized string) {
+	localized = strings.ReplaceAll(localized, "\u0301", "")
+	if localized == "" || localized == romanized {
+		return
+	}
+	if index[country] == nil {
+		index[country] = make(map[string]string)
+	}
+	if _, ok := index[country][romanized]; !ok {
+		index[country][romanized] = localized
+	}
+}
+
+func (id *IdentityData) GetLocalizedForename(isoCode, romanized string) string {
+	return id.LocalizedForenames[isoCode][romanized]
+}
+
+func (id *IdentityData) GetLocalizedSurname(isoCode, romanized string) string {
+	return id.LocalizedSurnames[isoCode][romanized]
+}
+
 func (id *IdentityData) GetForenames(isoCode string) []NameRecord {
 	return id.Forenames[isoCode]
 }
@@ -181,6 +231,40 @@ func (id *IdentityData) GetForenamesByGender(isoCode, gender string) []NameRecor
 	return filtered
 }
 
+func (id *IdentityData) GetForenamesByRegion(isoCode, gender, region string) []NameRecord {
+	var filtered []NameRecord
+	for _, n := range id.Forenames[isoCode] {
+		if n.Gender == gender && strings.EqualFold(n.Region, region) {
+			filtered = append(filtered, n)
+		}
+	}
+	return filtered
+}
+
+func (id *IdentityData) GetForenameRegions(isoCode string) []string {
+	regions := make([]string, 0)
+	seen := make(map[string]bool)
+
+	for _, n := range id.Forenames[isoCode] {
+		if n.Region != "" && !seen[n.Region] {
+			seen[n.Region] = true
+			regions = append(regions, n.Region)
+		}
+	}
+
+	sort.Strings(regions)
+	return regions
+}
+
+func (id *IdentityData) HasNationalForenames(isoCode string) bool {
+	for _, n := range id.Forenames[isoCode] {
+		if n.Region == "" {
+			return true
+		}
+	}
+	return false
+}
+
 func (id *IdentityData) GetSurnames(isoCode string) []SurnameRecord {
 	return id.Surnames[isoCode]
 }
@@ -131,6 +131,16 @@ func RecordsToFullMap(records []StatRecord) map[string]StatRecord {
 	return result
 }
 
+func RecordsToRegionMap(records []StatRecord) map[string]string {
+	result := make(map[string]string)
+	for _, r := range records {
+		if r.Region != "" {
+			result[r.Slug] = r.Region
+		}
+	}
+	return result
+}
+
 func LoadLifeExpectancyBySexCSV(filepath string) (map[string]LifeExpectancyBySex, error) {
 	data, err := os.ReadFile(filepath)
 	if err != nil {
@@ -0,0 +1,89 @@
+package data
+
+import "math"
+
+type Scenario string
+
+const (
+	ScenarioLow    Scenario = "low"
+	ScenarioMedium Scenario = "medium"
+	ScenarioHigh   Scenario = "high"
+)
+
+const (
+	fertilityConvergenceYears = 75
+	youthMortalityFloor       = 0.1
+)
+
+var (
+	fertilityTargets = map[Scenario]float64{
+		ScenarioLow:    1.25,
+		ScenarioMedium: 1.75,
+		ScenarioHigh:   2.25,
+	}
+	youthMortalityDecline = map[Scenario]float64{
+		ScenarioLow:    0.01,
+		ScenarioMedium: 0.02,
+		ScenarioHigh:   0.03,
+	}
+	mortalityImprovement = map[Scenario]float64{
+		ScenarioLow:    0.004,
+		ScenarioMedium: 0.008,
+		ScenarioHigh:   0.012,
+	}
+)
+
+type Projection func(last HistoricalRecord, year int, scenario Scenario) float64
+
+func ParseScenario(value string) Scenario {
+	switch Scenario(value) {
+	case ScenarioLow, ScenarioMedium, ScenarioHigh:
+		return Scenario(value)
+	default:
+		return ScenarioMedium
+	}
+}
+
+func convergeProjection(targets map[Scenario]float64, years int) Projection {
+	return func(last HistoricalRecord, year int, scenario Scenario) float64 {
+		share := float64(year-last.Year) / float64(years)
+		if share > 1 {
+			share = 1
+		}
+		return last.Value + share*(targets[scenario]-last.Value)
+	}
+}
+
+func declineProjection(rates map[Scenario]float64, floor float64) Projection {
+	return func(last HistoricalRecord, year int, scenario Scenario) float64 {
+		value := last.Value * math.Exp(-rates[scenario]*float64(year-last.Year))
+		if value < floor {
+			return math.Min(floor, last.Value)
+		}
+		return value
+	}
+}
+
+func (r *Repository) WithScenario(scenario Scenario) *Repository {
+	projected := *r
+	projected.scenario = ParseScenario(string(scenario))
+	return &projected
+}
+
+func (r *Repository) Scenario() Scenario {
+	return r.scenario
+}
+
+func (r *Repository) GetMortalityImprovementRate() float64 {
+	if r.scenario == "" {
+		return mortalityImprovement[ScenarioMedium]
+	}
+	return mortalityImprovement[r.scenario]
+}
+
+func (r *Repository) projectedValue(dataset *HistoricalDataset, iso3 string, year int, defaultVal float64) float64 {
+	if value, ok := dataset.GetProjectedValue(iso3, year, r.scenario); ok {
+		return value
+	}
+	return defaultVal
+}
@@ -2,10 +2,37 @@ package data
 
 import (
 	"fmt"
+	"math"
 	"sort"
 	"strings"
 )
 
+const (
+	modernGrowthRate      = 0.018
+	preindustrialGrowth   = 0.005
+	industrialisationYear = 1870
+	minPopulationGrowth   = 0.002
+	maxPopulationGrowth   = 0.03
+	populationYear        = 2024
+
+	youthMortalityBackcastRate = 0.03
+	minTrendYears              = 10
+	maxYouthMortality          = 35.0
+)
+
+// usdConsumerPriceIndex deflates gdp.csv, which is in current US dollars, for
+// every country alike; local inflation and exchange rates are not modelled.
+var usdConsumerPriceIndex = &HistoricalDataset{
+	Name: "US consumer price index",
+	ByCode: map[string][]HistoricalRecord{"USA": {
+		{Year: 1960, Value: 29.6}, {Year: 1965, Value: 31.5}, {Year: 1970, Value: 38.8},
+		{Year: 1975, Value: 53.8}, {Year: 1980, Value: 82.4}, {Year: 1985, Value: 107.6},
+		{Year: 1990, Value: 130.7}, {Year: 1995, Value: 152.4}, {Year: 2000, Value: 172.2},
+		{Year: 2005, Value: 195.3}, {Year: 2010, Value: 218.1}, {Year: 2015, Value: 237.0},
+		{Year: 2020, Value: 258.8}, {Year: 2023, Value: 304.7},
+	}},
+}
+
 type Repository struct {
 	Demographic *DemographicData
 	Economic    *EconomicData
@@ -13,6 +40,8 @@ type Repository struct {
 	Identity    *IdentityData
 	Historical  *HistoricalData
 	dataDir     string
+	scenario    Scenario
+	profile     Profile
 }
 
 type CountryStats struct {
@@ -36,7 +65,7 @@ type CountryStats struct {
 }
 
 func NewRepository(dataDir string) (*Repository, error) {
-	r := &Repository{dataDir: dataDir}
+	r := &Repository{dataDir: dataDir, profile: ProfileModern}
 	var err error
 
 	r.Demographic, err = LoadDemographicData(dataDir)
@@ -155,6 +184,58 @@ func (r *Repository) GetForenamesByGender(slug, gender string) []NameRecord {
 	return r.Identity.GetForenamesByGender(isoCode, gender)
 }
 
+func (r *Repository) GetForenamesByRegion(slug, gender, region string) []NameRecord {
+	isoCode := r.Identity.GetISOCodeFromSlug(slug)
+	if isoCode == "" {
+		return nil
+	}
+	return r.Identity.GetForenamesByRegion(isoCode, gender, region)
+}
+
+func (r *Repository) GetLocalizedForename(slug, romanized string) string {
+	isoCode := r.Identity.GetISOCodeFromSlug(slug)
+	if isoCode == "" {
+		return ""
+	}
+	return r.Identity.GetLocalizedForename(isoCode, romanized)
+}
+
+func (r *Repository) GetLocalizedSurname(slug, romanized string) string {
+	isoCode := r.Identity.GetISOCodeFromSlug(slug)
+	if isoCode == "" {
+		return ""
+	}
+	return r.Identity.GetLocalizedSurname(isoCode, romanized)
+}
+
+func (r *Repository) GetNameRegions(slug string) []string {
+	isoCode := r.Identity.GetISOCodeFromSlug(slug)
+	if isoCode == "" {
+		return nil
+	}
+	return r.Identity.GetForenameRegions(isoCode)
+}
+
+func (r *Repository) HasNationalForenames(slug string) bool {
+	isoCode := r.Identity.GetISOCodeFromSlug(slug)
+	if isoCode == "" {
+		return false
+	}
+	return r.Identity.HasNationalForenames(isoCode)
+}
+
+func (r *Repository) ValidateRegion(slug, region string) error {
+	if region == "" {
+		return nil
+	}
+	for _, candidate := range r.GetNameRegions(slug) {
+		if strings.EqualFold(candidate, region) {
+			return nil
+		}
+	}
+	return fmt.Errorf("region '%s' not found for country '%s'", region, slug)
+}
+
 func (r *Repository) GetSurnames(slug string) []SurnameRecord {
 	isoCode := r.Identity.GetISOCodeFromSlug(slug)
 	if isoCode == "" {
@@ -167,27 +248,77 @@ func (r *Repository) GetGDPPerCapita(slug string) float64 {
 	return r.Economic.GetGDPPerCapita(slug)
 }
 
+func (r *Repository) GetGDPPerCapitaAt(slug string, year int) float64 {
+	current := r.Economic.GetGDPPerCapita(slug)
+	iso3 := GetISO3FromSlug(slug)
+	if current <= 0 || iso3 == "" || r.Historical.GDP == nil {
+		return current
+	}
+
+	latest, latestYear, ok := r.Historical.GDP.GetLatestValue(iso3)
+	if !ok || latest <= 0 || year >= latestYear {
+		return current
+	}
+
+	anchorYear := year
+	if first := r.Historical.GDP.ByCode[iso3][0]; year < first.Year {
+		anchorYear = first.Year
+	}
+
+	total, _ := r.Historical.GDP.GetValue(iso3, anchorYear)
+	years := float64(latestYear - anchorYear)
+	priceLevel := usdConsumerPriceIndex.GetValueOrDefault("USA", latestYear, 1) / usdConsumerPriceIndex.GetValueOrDefault("USA", anchorYear, 1)
+	realGrowth := (total / latest) * priceLevel
+	populationGrowth := math.Exp(-r.populationGrowthRate(slug) * years)
+
+	return current * realGrowth / populationGrowth * backcastFactor(year, anchorYear)
+}
+
+// populationGrowthRate backcasts population at one constant rate: today's
+// birth rate minus death rate plus net migration, clamped to 0.2–3% a year.
+// No historical population series is loaded, so GDP per capita before the
+// latest year divides gdp.csv by an extrapolated population, not an observed one.
+func (r *Repository) populationGrowthRate(slug string) float64 {
+	rate := (r.Demographic.GetBirthRate(slug) - r.Demographic.GetDeathRate(slug) + r.Demographic.GetMigrationRate(slug)) / 1000
+	return math.Max(minPopulationGrowth, math.Min(maxPopulationGrowth, rate))
+}
+
+func backcastFactor(year, anchorYear int) float64 {
+	factor := 1.0
+	for y := anchorYear; y > year; y-- {
+		if y > industrialisationYear {
+			factor /= 1 + modernGrowthRate
+		} else {
+			factor /= 1 + preindustrialGrowth
+		}
+	}
+	return factor
+}
+
 func (r *Repository) GetUnderweightU5(slug string) float64 {
 	return r.Health.GetUnderweightU5(slug)
 }
 
 func (r *Repository) GetFertilityRate(slug string, year int) float64 {
-	iso3 := GetISO3FromSlug(slug)
-	if iso3 == "" {
-		return 2.1
+	value := 2.1
+	if iso3 := GetISO3FromSlug(slug); iso3 != "" {
+		value = r.projectedValue(r.Historical.FertilityRate, iso3, year, 2.1)
 	}
-	return r.Historical.FertilityRate.GetValueOrDefault(iso3, year, 2.1)
+	return r.blendEarlyModern(slug, year, value, func(regime EarlyModernRegime) float64 { return regime.TotalFertility })
 }
 
 func (r *Repository) GetMarriageAgeWomen(slug string, year int) float64 {
-	iso3 := GetISO3FromSlug(slug)
-	if iso3 == "" {
-		return 25.0
+	value := 25.0
+	if iso3 := GetISO3FromSlug(slug); iso3 != "" {
+		value = r.Historical.MarriageAgeWomen.GetValueOrDefault(iso3, year, 25.0)
 	}
-	return r.Historical.MarriageAgeWomen.GetValueOrDefault(iso3, year, 25.0)
+	return r.blendEarlyModern(slug, year, value, func(regime EarlyModernRegime) float64 { return regime.WomenMarriageAge })
 }
 
 func (r *Repository) GetDivorceRate(slug string, year int) float64 {
+	if r.InEarlyModernProfile(year) {
+		return 0
+	}
 	iso3 := GetISO3FromSlug(slug)
 	if iso3 == "" {
 		return 2.0
@@ -195,20 +326,69 @@ func (r *Repository) GetDivorceRate(slug string, year int) float64 {
 	return r.Historical.DivorceRate.GetValueOrDefault(iso3, year, 2.0)
 }
 
+func (r *Repository) GetDivorceRateFirstYear(slug string) (int, bool) {
+	iso3 := GetISO3FromSlug(slug)
+	if iso3 == "" {
+		return 0, false
+	}
+	return r.Historical.DivorceRate.GetFirstYear(iso3)
+}
+
 func (r *Repository) GetYouthMortality(slug string, year int) float64 {
+	return r.blendEarlyModern(slug, year, r.youthMortality(slug, year), func(regime EarlyModernRegime) float64 { return regime.YouthMortality })
+}
+
+func (r *Repository) youthMortality(slug string, year int) float64 {
 	iso3 := GetISO3FromSlug(slug)
 	if iso3 == "" {
 		return 5.0
 	}
-	return r.Historical.YouthMortality.GetValueOrDefault(iso3, year, 5.0)
+	records := r.Historical.YouthMortality.ByCode[iso3]
+	if len(records) == 0 || year >= records[0].Year {
+		return r.projectedValue(r.Historical.YouthMortality, iso3, year, 5.0)
+	}
+
+	first := records[0]
+	value := first.Value * math.Exp(youthMortalityBackcastRate*float64(first.Year-year))
+	return math.Min(value, maxYouthMortality)
 }
 
-func (r *Repository) GetBirthsOutsideMarriage(slug string, year int) float64 {
+func (r *Repository) GetYouthMortalityDecline(slug string) (float64, bool) {
 	iso3 := GetISO3FromSlug(slug)
 	if iso3 == "" {
-		return 20.0
+		return 0, false
 	}
-	return r.Historical.BirthsOutsideMarriage.GetValueOrDefault(iso3, year, 20.0)
+	records := r.Historical.YouthMortality.ByCode[iso3]
+	if len(records) < 2 || records[len(records)-1].Year-records[0].Year < minTrendYears {
+		return 0, false
+	}
+
+	var sumX, sumY, sumXY, sumXX float64
+	var n float64
+	for _, record := range records {
+		if record.Value <= 0 {
+			continue
+		}
+		x, y := float64(record.Year), math.Log(record.Value)
+		sumX += x
+		sumY += y
+		sumXY += x * y
+		sumXX += x * x
+		n++
+	}
+	denominator := n*sumXX - sumX*sumX
+	if n < 2 || denominator == 0 {
+		return 0, false
+	}
+	return -(n*sumXY - sumX*sumY) / denominator, true
+}
+
+func (r *Repository) GetBirthsOutsideMarriage(slug string, year int) float64 {
+	value := 20.0
+	if iso3 := GetISO3FromSlug(slug); iso3 != "" {
+		value = r.Historical.BirthsOutsideMarriage.GetValueOrDefault(iso3, year, 20.0)
+	}
+	return r.blendEarlyModern(slug, year, value, func(regime EarlyModernRegime) float64 { return regime.BirthsOutsideMarriage })
 }
 
 func (r *Repository) GetUrbanShare(slug string, year int) float64 {
@@ -230,17 +410,141 @@ func (r *Repository) GetUrbanShare(slug string, year int) float64 {
 }
 
 func (r *Repository) GetMarriageRate(slug string, year int) float64 {
+	value := 5.0
+	if iso3 := GetISO3FromSlug(slug); iso3 != "" {
+		value = r.Historical.MarriageRate.GetValueOrDefault(iso3, year, 5.0)
+	}
+	return r.blendEarlyModern(slug, year, value, func(regime EarlyModernRegime) float64 { return regime.MarriageRate })
+}
+
+func (r *Repository) GetSingleParentShare(slug string, year int) float64 {
+	value := 10.0
+	if iso3 := GetISO3FromSlug(slug); iso3 != "" {
+		value = r.Historical.SingleParentShare.GetValueOrDefault(iso3, year, 10.0)
+	}
+	return r.blendEarlyModern(slug, year, value, func(regime EarlyModernRegime) float64 { return regime.SingleParentShare })
+}
+
+func (r *Repository) GetRegion(slug string) string {
+	return r.Demographic.GetRegion(slug)
+}
+
+func (r *Repository) GetPopulation(slug string) float64 {
+	return r.Demographic.GetPopulation(slug)
+}
+
+func (r *Repository) GetPopulationAt(slug string, year int) float64 {
+	population := r.Demographic.GetPopulation(slug)
+	if population <= 0 || year >= populationYear {
+		return population
+	}
+	return population * math.Exp(-r.populationGrowthRate(slug)*float64(populationYear-year))
+}
+
+func (r *Repository) GetMigrationRate(slug string) float64 {
+	return r.Demographic.GetMigrationRate(slug)
+}
+
+// GetEmigrantShare is the emigrant stock as a share of everyone born in the
+// country and alive in that year, using the backcast population. The stock is
+// only observed from 1990 in five-year steps; earlier years return the first
+// observation.
+func (r *Repository) GetEmigrantShare(slug string, year int) float64 {
+	iso3 := GetISO3FromSlug(slug)
+	population := r.GetPopulationAt(slug, year)
+	if iso3 == "" || population <= 0 {
+		return 0
+	}
+	emigrants, ok := r.Historical.Emigrants.GetValue(iso3, year)
+	if !ok {
+		return 0
+	}
+	return emigrants / (population + emigrants)
+}
+
+func (r *Repository) GetEmigrantsFirstYear(slug string) (int, bool) {
 	iso3 := GetISO3FromSlug(slug)
 	if iso3 == "" {
-		return 5.0
+		return 0, false
 	}
-	return r.Historical.MarriageRate.GetValueOrDefault(iso3, year, 5.0)
+	return r.Historical.Emigrants.GetFirstYear(iso3)
 }
 
-func (r *Repository) GetSingleParentShare(slug string, year int) float64 {
+func (r *Repository) GetLiteracyRate(slug string, year int) float64 {
 	iso3 := GetISO3FromSlug(slug)
 	if iso3 == "" {
-		return 10.0
+		return 80.0
+	}
+	return r.Historical.LiteracyRate.GetValueOrDefault(iso3, year, 80.0)
+}
+
+func (r *Repository) GetAverageHeight(slug, gender string, birthYear int) float64 {
+	dataset, defaultVal := r.Historical.HeightMen, 171.0
+	if gender == "F" {
+		dataset, defaultVal = r.Historical.HeightWomen, 159.0
+	}
+	iso3 := GetISO3FromSlug(slug)
+	if iso3 == "" || dataset == nil {
+		return defaultVal
+	}
+	return dataset.GetValueOrDefault(iso3, birthYear, defaultVal)
+}
+
+func (r *Repository) GetCohortFertilitySchedule(slug string, motherBirthYear int) ([]int, []float64) {
+	return r.fertilitySchedule(slug, func(age int) int { return motherBirthYear + age })
+}
+
+func (r *Repository) GetPeriodFertilitySchedule(slug string, year int) ([]int, []float64) {
+	return r.fertilitySchedule(slug, func(int) int { return year })
+}
+
+func (r *Repository) fertilitySchedule(slug string, yearAtAge func(age int) int) ([]int, []float64) {
+	if r.InEarlyModernProfile(yearAtAge(30)) {
+		return r.earlyModernFertilitySchedule(slug)
+	}
+
+	iso3 := GetISO3FromSlug(slug)
+	if iso3 == "" || r.Historical.MotherAgeFertility == nil {
+		return nil, nil
+	}
+
+	ages := r.Historical.MotherAgeFertility.GetAges()
+	available := make([]int, 0, len(ages))
+	rates := make([]float64, 0, len(ages))
+	for _, age := range ages {
+		rate, ok := r.Historical.MotherAgeFertility.GetValue(iso3, age, yearAtAge(age))
+		if !ok {
+			continue
+		}
+		available = append(available, age)
+		rates = append(rates, rate)
+	}
+	return available, rates
+}
+
+type SameSexUnionStatus string
+
+const (
+	SameSexUnrecognised SameSexUnionStatus = "unrecognised"
+	SameSexCivilUnion   SameSexUnionStatus = "civil_union"
+	SameSexMarriage     SameSexUnionStatus = "marriage"
+)
+
+func (r *Repository) GetSameSexUnionStatus(slug string, year int) SameSexUnionStatus {
+	iso3 := GetISO3FromSlug(slug)
+	if iso3 == "" || r.Historical.SameSexMarriage == nil {
+		return SameSexUnrecognised
+	}
+	status, ok := r.Historical.SameSexMarriage.GetStatus(iso3, year)
+	if !ok {
+		return SameSexUnrecognised
+	}
+	switch status {
+	case "Legal", "Same-sex marriage":
+		return SameSexMarriage
+	case "Civil union or other partnership":
+		return SameSexCivilUnion
+	default:
+		return SameSexUnrecognised
 	}
-	return r.Historical.SingleParentShare.GetValueOrDefault(iso3, year, 10.0)
 }
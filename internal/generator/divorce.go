@@ -0,0 +1,108 @@
+package generator
+
+import (
+	"math"
+	"time"
+)
+
+const (
+	maxUnionDuration      = 50
+	durationHazardScale   = 5.0
+	maxDivorceShare       = 0.7
+	cohabitationDissolve  = 1.5
+	divorceLiberalYear    = 1965
+	divorceHistoricYear   = 1900
+	divorceHistoricFactor = 0.2
+)
+
+var unionDurationWeights = durationWeights()
+
+func durationWeights() []float64 {
+	weights := make([]float64, maxUnionDuration)
+	var total float64
+	for d := range weights {
+		x := float64(d) + 0.5
+		weights[d] = x * math.Exp(-x/durationHazardScale)
+		total += weights[d]
+	}
+	for d := range weights {
+		weights[d] /= total
+	}
+	return weights
+}
+
+func (p *ProbabilityEngine) SampleDivorceDate(marriageDate time.Time, partnerAge, otherAge int) *time.Time {
+	factor := (marriageAgeFactor(partnerAge) + marriageAgeFactor(otherAge)) / 2
+	return p.sampleDissolution(marriageDate, factor)
+}
+
+func (p *ProbabilityEngine) SampleSeparationDate(startDate time.Time, partnerAge, otherAge int) *time.Time {
+	factor := (marriageAgeFactor(partnerAge) + marriageAgeFactor(otherAge)) / 2
+	return p.sampleDissolution(startDate, factor*cohabitationDissolve)
+}
+
+func (p *ProbabilityEngine) sampleDissolution(start time.Time, factor float64) *time.Time {
+	u := p.rng.Float64()
+	for u == 0 {
+		u = p.rng.Float64()
+	}
+	target := -math.Log(u)
+
+	var cumulative float64
+	for d, weight := range unionDurationWeights {
+		h := p.divorceIntensity(start.Year()+d) * weight * factor
+		if cumulative+h >= target {
+			days := int((float64(d) + (target-cumulative)/h) * daysPerYear)
+			date := start.AddDate(0, 0, days)
+			return &date
+		}
+		cumulative += h
+	}
+	return nil
+}
+
+func (p *ProbabilityEngine) divorceIntensity(year int) float64 {
+	marriageRate := p.repo.GetMarriageRate(p.country, year)
+	if marriageRate <= 0 {
+		return 0
+	}
+
+	share := p.repo.GetDivorceRate(p.country, year) / marriageRate * p.divorceBackcastFactor(year)
+	if share > maxDivorceShare {
+		share = maxDivorceShare
+	}
+	return -math.Log(1 - share)
+}
+
+func (p *ProbabilityEngine) divorceBackcastFactor(year int) float64 {
+	firstYear, ok := p.repo.GetDivorceRateFirstYear(p.country)
+	if !ok {
+		firstYear = divorceLiberalYear
+	}
+	return divorceHistoryFactor(year, firstYear)
+}
+
+func divorceHistoryFactor(year, firstObservedYear int) float64 {
+	if year >= firstObservedYear {
+		return 1
+	}
+	if year <= divorceHistoricYear {
+		return divorceHistoricFactor
+	}
+	share := float64(year-divorceHistoricYear) / float64(firstObservedYear-divorceHistoricYear)
+	return divorceHistoricFactor + share*(1-divorceHistoricFactor)
+}
+
+func marriageAgeFactor(age int) float64 {
+	switch {
+	case age < 20:
+		return 1.6
+	case age < 25:
+		return 1.2
+	case age < 30:
+		return 1.0
+	case age < 35:
+		return 0.85
+	}
+	return 0.75
+}
@@ -0,0 +1,100 @@
+package generator
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/familytree-generator/internal/data"
+	"github.com/familytree-generator/pkg/rand"
+)
+
+func TestDivorceHistoryFactor(t *testing.T) {
+	tests := []struct {
+		name      string
+		year      int
+		firstYear int
+		want      float64
+	}{
+		{"observed year", 1960, 1960, 1},
+		{"after first observation", 1990, 1960, 1},
+		{"before 1900", 1850, 1960, divorceHistoricFactor},
+		{"halfway to first observation", 1930, 1960, (1 + divorceHistoricFactor) / 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := divorceHistoryFactor(tt.year, tt.firstYear); math.Abs(got-tt.want) > 1e-9 {
+				t.Errorf("divorceHistoryFactor(%d, %d) = %v, want %v", tt.year, tt.firstYear, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDivorceBackcastStartsBeforeFirstObservation(t *testing.T) {
+	repo := loadRepository(t)
+	prob := NewProbabilityEngine(rand.New(1), repo.GetCountryStats("germany"), repo, "germany", LifeExpectancyTotal)
+
+	firstYear, ok := repo.GetDivorceRateFirstYear("germany")
+	if !ok {
+		t.Fatal("no divorce data for germany")
+	}
+	if got := prob.divorceBackcastFactor(firstYear); got != 1 {
+		t.Errorf("divorceBackcastFactor(%d) = %v, want 1 for an observed year", firstYear, got)
+	}
+	if got := prob.divorceBackcastFactor(firstYear - 1); got >= 1 {
+		t.Errorf("divorceBackcastFactor(%d) = %v, want < 1 before the first observation", firstYear-1, got)
+	}
+}
+
+// US divorces per marriage stayed near 0.5 from 1990 on, and with a flat
+// ratio the period ratio equals the share of a marriage cohort that divorces.
+func TestDivorceShareMatchesDivorceRates(t *testing.T) {
+	repo := loadRepository(t)
+
+	const (
+		country  = "united-states"
+		iso3     = "USA"
+		decade   = 1990
+		unions   = 20000
+		marriage = 28
+	)
+	prob := NewProbabilityEngine(rand.New(1), repo.GetCountryStats(country), repo, country, LifeExpectancyTotal)
+
+	var divorced int
+	for i := 0; i < unions; i++ {
+		start := time.Date(decade+i%10, time.June, 1, 0, 0, 0, 0, time.UTC)
+		if prob.SampleDivorceDate(start, marriage, marriage) != nil {
+			divorced++
+		}
+	}
+
+	var divorces, marriages float64
+	for _, r := range repo.Historical.DivorceRate.ByCode[iso3] {
+		if r.Year < decade {
+			continue
+		}
+		if rate, ok := repo.Historical.MarriageRate.GetValue(iso3, r.Year); ok {
+			divorces += r.Value
+			marriages += rate
+		}
+	}
+	if marriages == 0 {
+		t.Fatalf("no marriage data for %s", country)
+	}
+
+	got := float64(divorced) / unions
+	want := divorces / marriages
+	if math.Abs(got-want) > 0.03 {
+		t.Errorf("divorce share for %s marriages in the %ds = %.3f, divorces per marriage since %d in the source data = %.3f", country, decade, got, decade, want)
+	}
+}
+
+func loadRepository(t *testing.T) *data.Repository {
+	t.Helper()
+	repo, err := data.NewRepository("../../data")
+	if err != nil {
+		t.Fatalf("loading data: %v", err)
+	}
+	return repo
+}
@@ -0,0 +1,157 @@
+package generator
+
+import (
+	"sort"
+
+	"github.com/familytree-generator/internal/data"
+	"github.com/familytree-generator/internal/model"
+)
+
+const (
+	parishPatternShare = 0.65
+	necronymShare      = 0.5
+)
+
+var earlyModernAssumptions = []string{
+	"Marriage age, marriage rate, fertility, under-15 mortality, births outside marriage and single-mother families follow the country's marriage pattern and blend linearly into the observed series between 1850 and 1900.",
+	"Births follow natural marital fertility, weighted by the share of women married at each age.",
+	"Divorce and separation do not occur before 1900; unions end only by death.",
+	"Step-children are not formally adopted before 1900.",
+	"Forenames come from a ranked parish register list for the country's name group, or the modern national list where no group exists.",
+	"Sons are named after the paternal grandfather, maternal grandfather and father in birth order; daughters after the maternal grandmother, paternal grandmother and mother.",
+	"A child may take the name of a same-sex sibling who died before it was born.",
+}
+
+func (e *Engine) recordProfile() {
+	if e.repo.Profile() != data.ProfileEarlyModern {
+		return
+	}
+
+	seen := map[string]bool{e.config.Country: true}
+	countries := []string{e.config.Country}
+	for _, p := range e.tree.GetAllPersons() {
+		if e.repo.InEarlyModernProfile(p.BirthDate.Year()) && !seen[p.BirthCountry] {
+			seen[p.BirthCountry] = true
+			countries = append(countries, p.BirthCountry)
+		}
+	}
+	sort.Strings(countries)
+
+	profile := &model.DemographicProfile{
+		Name:        string(data.ProfileEarlyModern),
+		FromYear:    data.EarlyModernFrom,
+		UntilYear:   data.EarlyModernUntil,
+		BlendFrom:   data.EarlyModernBlendFrom,
+		Countries:   make([]model.CountryAssumptions, 0, len(countries)),
+		Assumptions: earlyModernAssumptions,
+	}
+	for _, country := range countries {
+		regime := e.repo.GetEarlyModernRegime(country)
+		profile.Countries = append(profile.Countries, model.CountryAssumptions{
+			Country:               country,
+			MarriagePattern:       regime.Pattern,
+			WomenMarriageAge:      regime.WomenMarriageAge,
+			MarriageRate:          regime.MarriageRate,
+			TotalFertility:        regime.TotalFertility,
+			YouthMortality:        regime.YouthMortality,
+			BirthsOutsideMarriage: regime.BirthsOutsideMarriage,
+			SingleParentShare:     regime.SingleParentShare,
+			NameGroup:             regime.NameGroup,
+		})
+	}
+	e.tree.Profile = profile
+}
+
+func (e *Engine) applyParishNaming() {
+	if e.repo.Profile() != data.ProfileEarlyModern {
+		return
+	}
+
+	persons := e.tree.GetAllPersons()
+	sort.Slice(persons, func(i, j int) bool {
+		if !persons[i].BirthDate.Equal(persons[j].BirthDate) {
+			return persons[i].BirthDate.Before(persons[j].BirthDate)
+		}
+		return persons[i].ID < persons[j].ID
+	})
+
+	for _, p := range persons {
+		if p.FatherID == nil && p.MotherID == nil {
+			continue
+		}
+		if custom := namingCustomFor(p.BirthCountry); custom.patronymic != patronymicNone || custom.surnames == surnamePatronymic {
+			continue
+		}
+		if e.repo.InEarlyModernProfile(p.BirthDate.Year()) {
+			p.NamesakeID = nil
+			p.Suffix = ""
+			e.personGen.applyNamingTradition(p, e.tree)
+		}
+	}
+}
+
+func (g *PersonGenerator) parishNamesake(child *model.Person, tree *model.FamilyTree, father, mother *model.Person, taken map[string]bool) *model.Person {
+	weight := g.repo.EarlyModernWeight(child.BirthDate.Year())
+	if weight == 0 || !g.rng.Chance(weight) {
+		return nil
+	}
+
+	older := olderSiblings(child, tree, father, mother)
+	var deceased *model.Person
+	for _, sibling := range older {
+		if sibling.DeathDate != nil && sibling.DeathDate.Before(child.BirthDate) && !taken[sibling.FirstName] {
+			if deceased == nil || sibling.DeathDate.After(*deceased.DeathDate) {
+				deceased = sibling
+			}
+		}
+	}
+	if deceased != nil && g.rng.Chance(necronymShare) {
+		return deceased
+	}
+
+	order := parishNamingOrder(child.Gender, tree, father, mother)
+	if len(older) >= len(order) {
+		return nil
+	}
+	namesake := order[len(older)]
+	if namesake == nil || taken[namesake.FirstName] || !g.rng.Chance(parishPatternShare) {
+		return nil
+	}
+	return namesake
+}
+
+func parishNamingOrder(gender model.Gender, tree *model.FamilyTree, father, mother *model.Person) []*model.Person {
+	if gender == model.Male {
+		return []*model.Person{grandparentVia(tree, father, gender), grandparentVia(tree, mother, gender), father}
+	}
+	return []*model.Person{grandparentVia(tree, mother, gender), grandparentVia(tree, father, gender), mother}
+}
+
+func grandparentVia(tree *model.FamilyTree, parent *model.Person, gender model.Gender) *model.Person {
+	if parent == nil {
+		return nil
+	}
+	if gender == model.Male {
+		return treePerson(tree, parent.FatherID)
+	}
+	return treePerson(tree, parent.MotherID)
+}
+
+func olderSiblings(child *model.Person, tree *model.FamilyTree, father, mother *model.Person) []*model.Person {
+	seen := make(map[string]bool)
+	var older []*model.Person
+	for _, parent := range []*model.Person{father, mother} {
+		if parent == nil {
+			continue
+		}
+		for _, id := range parent.ChildrenIDs {
+			sibling := tree.GetPerson(id)
+			if sibling == nil || seen[id] || sibling.Gender != child.Gender || !sibling.BirthDate.Before(child.BirthDate) {
+				continue
+			}
+			seen[id] = true
+			older = append(older, sibling)
+		}
+	}
+	return older
+}
@@ -2,6 +2,7 @@ package generator
 
 import (
 	"fmt"
+	"sort"
 	"time"
 
 	"github.com/familytree-generator/internal/data"
@@ -10,24 +11,39 @@ import (
 )
 
 type Config struct {
-	Country            string
-	Generations        int
-	Seed               int64
-	StartYear          int
-	RootGender         model.Gender
-	IncludeExtended    bool
-	LifeExpectancyMode LifeExpectancyMode
+	Country               string
+	AncestorGenerations   int
+	DescendantGenerations int
+	Seed                  int64
+	StartYear             int
+	RootGender            model.Gender
+	Region                string
+	HostNameShare         float64
+	IncludeExtended       bool
+	CollateralDepth       int
+	MaxPersons            int
+	LifeExpectancyMode    LifeExpectancyMode
+	AsOf                  time.Time
+	Scenario              data.Scenario
+	ProjectUntil          int
+	Profile               data.Profile
 }
 
 func DefaultConfig() Config {
 	return Config{
-		Country:            "germany",
-		Generations:        3,
-		Seed:               time.Now().UnixNano(),
-		StartYear:          1970,
-		RootGender:         "",
-		IncludeExtended:    false,
-		LifeExpectancyMode: LifeExpectancyTotal,
+		Country:               "germany",
+		AncestorGenerations:   2,
+		DescendantGenerations: 2,
+		Seed:                  time.Now().UnixNano(),
+		StartYear:             1970,
+		RootGender:            "",
+		HostNameShare:         defaultHostNameShare,
+		IncludeExtended:       false,
+		CollateralDepth:       0,
+		MaxPersons:            defaultMaxPersons,
+		LifeExpectancyMode:    LifeExpectancyByGender,
+		Scenario:              data.ScenarioMedium,
+		Profile:               data.ProfileModern,
 	}
 }
 
@@ -38,10 +54,42 @@ type Engine struct {
 	tree      *model.FamilyTree
 	personGen *PersonGenerator
 	familyBld *FamilyBuilder
+
+	collateral   []collateralLine
+	personBudget int
+}
+
+type collateralLine struct {
+	person      *model.Person
+	generations int
+}
+
+func ParseAsOf(value string) (time.Time, error) {
+	if value == "" {
+		return time.Time{}, nil
+	}
+	if date, err := time.Parse("2006-01-02", value); err == nil {
+		return date, nil
+	}
+	year, err := time.Parse("2006", value)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("parsing date %q: expected YYYY-MM-DD or YYYY", value)
+	}
+	return time.Date(year.Year(), time.December, 31, 0, 0, 0, 0, time.UTC), nil
 }
 
 func NewEngine(config Config, repo *data.Repository) *Engine {
 	rng := rand.New(config.Seed)
+	config.Scenario = data.ParseScenario(string(config.Scenario))
+	config.Profile = data.ParseProfile(string(config.Profile))
+	if config.AsOf.IsZero() {
+		now := time.Now().UTC()
+		config.AsOf = time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+	}
+	repo = repo.WithProfile(config.Profile)
+	if config.ProjectUntil > config.AsOf.Year() {
+		repo = repo.WithScenario(config.Scenario)
+	}
 
 	e := &Engine{
 		config: config,
@@ -50,8 +98,14 @@ func NewEngine(config Config, repo *data.Repository) *Engine {
 	}
 
 	e.personGen = NewPersonGenerator(rng, repo, config.Country, config.LifeExpectancyMode)
+	e.personGen.useHostNameShare(config.HostNameShare)
+	e.personGen.useProjection(e.config.AsOf, e.config.ProjectUntil)
 	e.familyBld = NewFamilyBuilder(rng, e.personGen)
 
+	if e.config.MaxPersons <= 0 {
+		e.config.MaxPersons = defaultMaxPersons
+	}
+
 	return e
 }
 
@@ -60,9 +114,13 @@ func (e *Engine) Generate() (*model.FamilyTree, error) {
 	if err := e.repo.ValidateCountry(e.config.Country); err != nil {
 		return nil, fmt.Errorf("invalid country: %w", err)
 	}
+	if err := e.repo.ValidateRegion(e.config.Country, e.config.Region); err != nil {
+		return nil, fmt.Errorf("invalid region: %w", err)
+	}
 
 	treeID := fmt.Sprintf("tree_%d", e.config.Seed)
-	e.tree = model.NewFamilyTree(treeID, e.config.Country, e.config.Generations, e.config.Seed)
+	e.tree = model.NewFamilyTree(treeID, e.config.Country, e.config.AncestorGenerations, e.config.DescendantGenerations, e.config.Seed)
+	e.tree.Region = e.personGen.useNameRegion(e.config.Region)
 
 	rootGender := e.config.RootGender
 	if rootGender == "" {
@@ -76,28 +134,36 @@ func (e *Engine) Generate() (*model.FamilyTree, error) {
 	})
 
 	e.tree.SetRootPerson(root)
+	e.collateral = nil
 
-	e.generateAncestors(root, e.config.Generations-1)
-
-	e.generateDescendants(root, e.config.Generations-1)
+	e.generateAncestors(root, e.config.AncestorGenerations)
+	e.applyParishNaming()
 
-	e.applyReferenceYearMortality()
+	e.generateDescendants(root, e.config.DescendantGenerations)
 
-	return e.tree, nil
-}
+	e.generateCollateralLines()
 
-func (e *Engine) applyReferenceYearMortality() {
-	referenceYear := e.calculateReferenceYear()
-	if referenceYear == 0 {
-		return
+	e.tree.AsOf = e.config.AsOf
+	if !e.personGen.projectUntil.IsZero() {
+		e.tree.Scenario = string(e.config.Scenario)
+		e.tree.ProjectUntil = e.config.ProjectUntil
 	}
+	e.applyReferenceDateMortality()
 
-	refDate := time.Date(referenceYear, time.December, 31, 0, 0, 0, 0, time.UTC)
-	prob := e.personGen.GetProbabilityEngine()
+	e.assignFosterCare()
+	e.recordWidowhood()
+	e.assignMaritalStatus()
+	e.markProjectedEvents()
+	e.recordProfile()
 
+	return e.tree, nil
+}
+
+func (e *Engine) applyReferenceDateMortality() {
+	refDate := e.personGen.observedUntil()
 	for _, p := range e.tree.GetAllPersons() {
-		maxAge := prob.MaxAllowedAge(p.BirthDate.Year(), p.Gender)
-		ageAtReference := referenceYear - p.BirthDate.Year()
+		maxAge := e.personGen.GetProbabilityEngineFor(p.BirthCountry).MaxAllowedAge(p.BirthDate.Year(), p.Gender)
+		ageAtReference := p.Age(refDate)
 		if ageAtReference < 0 {
 			ageAtReference = 0
 		}
@@ -130,52 +196,42 @@ func (e *Engine) applyReferenceYearMortality() {
 	}
 }
 
-func (e *Engine) calculateReferenceYear() int {
-	persons := e.tree.GetAllPersons()
-	if len(persons) == 0 {
-		return 0
-	}
+func (e *Engine) recordWidowhood() {
+	families := e.tree.GetAllFamilies()
+	sort.Slice(families, func(i, j int) bool {
+		return families[i].ID < families[j].ID
+	})
 
-	maxGeneration := 0
-	for _, p := range persons {
-		if p.Generation > maxGeneration {
-			maxGeneration = p.Generation
+	for _, family := range families {
+		if !family.IsLegalUnion() || !family.IsCouple() {
+			continue
 		}
-	}
 
-	var latestEvent time.Time
-	for _, p := range persons {
-		if p.Generation != maxGeneration {
+		partner := e.tree.GetPerson(family.PartnerIDs[0])
+		other := e.tree.GetPerson(family.PartnerIDs[1])
+		if partner == nil || other == nil {
 			continue
 		}
-		for _, ev := range p.Events {
-			if ev.Date.After(latestEvent) {
-				latestEvent = ev.Date
+
+		for _, pair := range [][2]*model.Person{{partner, other}, {other, partner}} {
+			deceased, survivor := pair[0], pair[1]
+			if deceased.DeathDate == nil || (family.DivorceDate != nil && !deceased.DeathDate.Before(*family.DivorceDate)) {
+				continue
 			}
+			if survivor.DeathDate != nil && !survivor.DeathDate.After(*deceased.DeathDate) {
+				continue
+			}
+			event := model.NewLifeEvent(model.EventWidowhood, *deceased.DeathDate, survivor.CountryAt(*deceased.DeathDate)).WithRelatedID(deceased.ID)
+			survivor.Events = append(survivor.Events, event)
 		}
-		if p.BirthDate.After(latestEvent) {
-			latestEvent = p.BirthDate
-		}
-		if p.DeathDate != nil && p.DeathDate.After(latestEvent) {
-			latestEvent = *p.DeathDate
-		}
-	}
-
-	if !latestEvent.IsZero() {
-		return latestEvent.Year()
 	}
+}
 
-	referenceYear := 0
-	for _, p := range persons {
-		birthYear := p.BirthDate.Year()
-		if birthYear > referenceYear {
-			referenceYear = birthYear
-		}
-	}
-	if referenceYear == 0 {
-		referenceYear = time.Now().Year()
+func (e *Engine) assignMaritalStatus() {
+	referenceDate := e.tree.ReferenceDate()
+	for _, p := range e.tree.GetAllPersons() {
+		p.MaritalStatus = p.MaritalStatusAt(referenceDate)
 	}
-	return referenceYear
 }
 
 func ensureDeathEvent(person *model.Person) {
@@ -208,23 +264,60 @@ func (e *Engine) generateAncestors(person *model.Person, remainingGenerations in
 
 	person.FatherID = &father.ID
 	person.MotherID = &mother.ID
+	person.AddParent(father.ID, model.BiologicalParent, nil)
+	person.AddParent(mother.ID, model.BiologicalParent, nil)
 	father.ChildrenIDs = append(father.ChildrenIDs, person.ID)
 	mother.ChildrenIDs = append(mother.ChildrenIDs, person.ID)
 
-	family := e.familyBld.LinkSpouses(father, mother, e.tree)
+	unionType := e.personGen.GetProbabilityEngineFor(person.BirthCountry).DetermineUnionType(person.BirthDate.Year())
+	var family *model.Family
+	if unionType == model.UnionNone {
+		family = e.familyBld.LinkSingleParent(mother, e.tree)
+	} else {
+		family = e.familyBld.LinkParents(father, mother, person, unionType, e.tree)
+	}
 
 	family.AddChild(person.ID)
+	person.BornOutsideMarriage = !family.MarriedBy(person.BirthDate)
+	e.personGen.inheritNames(person, father, mother)
+
+	if e.config.IncludeExtended || e.config.CollateralDepth > 0 {
+		siblings := e.familyBld.GenerateSiblings(family, person, father, mother, e.tree)
 
-	if e.config.IncludeExtended {
-		e.familyBld.GenerateSiblings(person, father, mother, e.tree)
+		removed := -person.Generation
+		if e.config.CollateralDepth > 0 && removed <= e.config.CollateralDepth {
+			for _, sibling := range siblings {
+				e.collateral = append(e.collateral, collateralLine{person: sibling, generations: removed + 1})
+			}
+		}
 	}
 
 	e.generateAncestors(father, remainingGenerations-1)
 	e.generateAncestors(mother, remainingGenerations-1)
 }
 
+func (e *Engine) generateCollateralLines() {
+	sort.SliceStable(e.collateral, func(i, j int) bool {
+		return e.collateral[i].generations < e.collateral[j].generations
+	})
+
+	e.personBudget = e.config.MaxPersons
+	defer func() { e.personBudget = 0 }()
+
+	for _, line := range e.collateral {
+		if e.overBudget() {
+			return
+		}
+		e.generateDescendants(line.person, line.generations)
+	}
+}
+
+func (e *Engine) overBudget() bool {
+	return e.personBudget > 0 && e.tree.PersonCount() >= e.personBudget
+}
+
 func (e *Engine) generateDescendants(person *model.Person, remainingGenerations int) {
-	if remainingGenerations <= 0 {
+	if remainingGenerations <= 0 || e.overBudget() {
 		return
 	}
 
@@ -235,27 +328,113 @@ func (e *Engine) generateDescendants(person *model.Person, remainingGenerations
 		}
 	}
 
-	spouse := e.personGen.GenerateSpouse(person)
+	prob := e.personGen.GetProbabilityEngineFor(person.BirthCountry)
+	if person.ID != e.tree.RootPersonID && !prob.ShouldGetMarried(person.BirthDate.Year()) {
+		return
+	}
+
+	unionYear := person.BirthDate.Year() + prob.CalculateMarriageAge(person.Gender, person.BirthDate.Year())
+	if person.ID != e.tree.RootPersonID && e.personGen.beyondProjection(time.Date(unionYear, time.January, 1, 0, 0, 0, 0, time.UTC)) {
+		return
+	}
+	unionType := prob.DetermineUnionType(unionYear)
+
+	if unionType == model.UnionNone {
+		family := e.familyBld.LinkSingleParent(person, e.tree)
+		father, mother := parentsByGender(person, nil)
+		children := e.familyBld.GenerateChildren(family, father, mother, e.tree)
+		for _, child := range children {
+			e.generateDescendants(child, remainingGenerations-1)
+		}
+		if len(children) > 0 {
+			lastBirth := children[len(children)-1].BirthDate
+			if family, spouse := e.generateLaterUnion(person, lastBirth, remainingGenerations); family != nil {
+				e.generateRemarriages(person, family, spouse, remainingGenerations)
+			}
+		}
+		return
+	}
+
+	sameSexType, sameSex := prob.DetermineSameSexUnion(unionYear)
+	if sameSex {
+		unionType = sameSexType
+	}
+
+	spouse := e.personGen.GenerateSpouse(person, sameSex)
 	e.tree.AddPerson(spouse)
 
-	var husband, wife *model.Person
-	if person.Gender == model.Male {
-		husband = person
-		wife = spouse
-	} else {
-		husband = spouse
-		wife = person
+	family := e.familyBld.LinkSpouses(person, spouse, unionType, e.tree)
+	e.generateUnionChildren(family, person, spouse, remainingGenerations)
+
+	e.generateRemarriages(person, family, spouse, remainingGenerations)
+}
+
+func (e *Engine) generateRemarriages(person *model.Person, family *model.Family, spouse *model.Person, remainingGenerations int) {
+	for unions := 1; unions < maxUnionsPerPerson; unions++ {
+		endDate := unionEndDate(family, person, spouse)
+		if endDate == nil {
+			return
+		}
+
+		family, spouse = e.generateLaterUnion(person, *endDate, remainingGenerations)
+		if family == nil {
+			return
+		}
+	}
+}
+
+func (e *Engine) generateLaterUnion(person *model.Person, after time.Time, remainingGenerations int) (*model.Family, *model.Person) {
+	prob := e.personGen.GetProbabilityEngineFor(person.BirthCountry)
+
+	remarriageDate := after.AddDate(e.rng.IntRange(1, 4), e.rng.IntRange(0, 11), e.rng.IntRange(0, 27))
+	if e.personGen.beyondProjection(remarriageDate) {
+		return nil, nil
+	}
+	if person.DeathDate != nil && !person.DeathDate.After(remarriageDate) {
+		return nil, nil
 	}
 
-	family := e.familyBld.LinkSpouses(husband, wife, e.tree)
+	age := person.Age(remarriageDate)
+	if age > maxRemarriageAge || !prob.ShouldRemarry(age) {
+		return nil, nil
+	}
 
-	children := e.familyBld.GenerateChildren(family, husband, wife, e.tree)
+	unionType, sameSex := prob.DetermineSameSexUnion(remarriageDate.Year())
+	if !sameSex {
+		unionType = model.UnionMarriage
+	}
+
+	spouse := e.personGen.GenerateRemarriageSpouse(person, remarriageDate, sameSex)
+	e.tree.AddPerson(spouse)
+
+	family := e.familyBld.LinkRemarriage(person, spouse, unionType, remarriageDate, e.tree)
+	e.familyBld.LinkStepChildren(person, spouse, remarriageDate, e.tree)
+	e.generateUnionChildren(family, person, spouse, remainingGenerations)
+
+	return family, spouse
+}
+
+func (e *Engine) generateUnionChildren(family *model.Family, person, spouse *model.Person, remainingGenerations int) {
+	var children []*model.Person
+	if family.SameSex {
+		children = e.familyBld.GenerateAdoptedChildren(family, person, spouse, e.tree)
+	} else {
+		father, mother := parentsByGender(person, spouse)
+		children = e.familyBld.GenerateChildren(family, father, mother, e.tree)
+	}
 
 	for _, child := range children {
 		e.generateDescendants(child, remainingGenerations-1)
 	}
 }
 
+func parentsByGender(person, partner *model.Person) (*model.Person, *model.Person) {
+	if person.Gender == model.Male {
+		return person, partner
+	}
+	return partner, person
+}
+
 func (e *Engine) GetTree() *model.FamilyTree {
 	return e.tree
 }
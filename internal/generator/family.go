@@ -22,120 +22,315 @@ func NewFamilyBuilder(rng *rand.SeededRandom, personGen *PersonGenerator) *Famil
 	}
 }
 
-func (b *FamilyBuilder) CreateFamily(husband, wife *model.Person) *model.Family {
-	b.familyCounter++
-	id := fmt.Sprintf("F%05d", b.familyCounter)
+func (b *FamilyBuilder) CreateFamily(partner, other *model.Person, unionType model.UnionType) *model.Family {
+	unionDate := b.sampleUnionDate(partner, other)
+	return b.createFamily([]*model.Person{partner, other}, unionType, &unionDate)
+}
 
-	marriageYear := b.calculateMarriageYear(husband, wife)
-	marriageDate := time.Date(marriageYear, time.Month(b.rng.IntRange(1, 12)), b.rng.IntRange(1, 28), 0, 0, 0, 0, time.UTC)
+func (b *FamilyBuilder) CreateParentsFamily(father, mother, child *model.Person, unionType model.UnionType) *model.Family {
+	unionDate := b.sampleUnionDate(father, mother)
+	if unionDate.After(child.BirthDate) {
+		unionDate = child.BirthDate.AddDate(0, -b.rng.IntRange(1, 12), -b.rng.IntRange(0, 27))
+	}
+	return b.createFamily([]*model.Person{father, mother}, unionType, &unionDate)
+}
 
-	family := model.NewFamily(id, marriageDate)
-	family.SetHusband(husband.ID)
-	family.SetWife(wife.ID)
+func (b *FamilyBuilder) sampleUnionDate(partner, other *model.Person) time.Time {
+	unionYear := b.calculateMarriageYear(partner, other)
+	return time.Date(unionYear, time.Month(b.rng.IntRange(1, 12)), b.rng.IntRange(1, 28), 0, 0, 0, 0, time.UTC)
+}
 
-	husband.SpouseIDs = append(husband.SpouseIDs, wife.ID)
-	wife.SpouseIDs = append(wife.SpouseIDs, husband.ID)
+func (b *FamilyBuilder) CreateRemarriage(partner, other *model.Person, unionType model.UnionType, marriageDate time.Time) *model.Family {
+	return b.createFamily([]*model.Person{partner, other}, unionType, &marriageDate)
+}
 
-	husband.MarriageAge = marriageYear - husband.BirthDate.Year()
-	wife.MarriageAge = marriageYear - wife.BirthDate.Year()
+func (b *FamilyBuilder) CreateSingleParentFamily(parent *model.Person) *model.Family {
+	return b.createFamily([]*model.Person{parent}, model.UnionNone, nil)
+}
 
-	husband.MaritalStatus = model.Married
-	wife.MaritalStatus = model.Married
+func (b *FamilyBuilder) createFamily(partners []*model.Person, unionType model.UnionType, unionDate *time.Time) *model.Family {
+	b.familyCounter++
+	id := fmt.Sprintf("F%05d", b.familyCounter)
+
+	family := model.NewFamily(id, unionType, unionDate)
+	for _, partner := range partners {
+		family.AddPartner(partner.ID)
+	}
+	if len(partners) == 2 {
+		family.SameSex = partners[0].Gender == partners[1].Gender
+	}
 
-	prob := b.personGen.GetProbabilityEngine()
-	if prob.ShouldGetDivorced(marriageYear) {
-		divorceYear := prob.CalculateDivorceYear(marriageYear)
-		divorceDate := time.Date(divorceYear, time.Month(b.rng.IntRange(1, 12)), b.rng.IntRange(1, 28), 0, 0, 0, 0, time.UTC)
+	switch unionType {
+	case model.UnionMarriage, model.UnionCivilPartnership:
+		b.formLegalUnion(family, partners[0], partners[1])
+	case model.UnionCohabitation:
+		b.formCohabitation(family, partners[0], partners[1])
+	default:
+		partners[0].IsSingleParent = true
+	}
 
-		husbandAlive := husband.DeathDate == nil || husband.DeathDate.After(divorceDate)
-		wifeAlive := wife.DeathDate == nil || wife.DeathDate.After(divorceDate)
+	return family
+}
 
-		if husbandAlive && wifeAlive {
-			family.DivorceDate = &divorceDate
-			husband.MaritalStatus = model.Divorced
-			wife.MaritalStatus = model.Divorced
+func (b *FamilyBuilder) formLegalUnion(family *model.Family, partner, other *model.Person) {
+	unionDate := *family.UnionDate
+	unionYear := unionDate.Year()
 
-			divorceEvent := model.NewLifeEvent(model.EventDivorce, divorceDate, husband.CurrentCountry).
-				WithRelatedID(wife.ID)
-			husband.Events = append(husband.Events, divorceEvent)
+	partner.SpouseIDs = append(partner.SpouseIDs, other.ID)
+	other.SpouseIDs = append(other.SpouseIDs, partner.ID)
 
-			divorceEventWife := model.NewLifeEvent(model.EventDivorce, divorceDate, wife.CurrentCountry).
-				WithRelatedID(husband.ID)
-			wife.Events = append(wife.Events, divorceEventWife)
+	for _, p := range []*model.Person{partner, other} {
+		if p.MarriageAge == 0 {
+			p.MarriageAge = unionYear - p.BirthDate.Year()
 		}
 	}
 
-	marriageEvent := model.NewLifeEvent(model.EventMarriage, marriageDate, husband.CurrentCountry).
-		WithRelatedID(wife.ID)
-	husband.Events = append(husband.Events, marriageEvent)
+	prob := b.personGen.GetProbabilityEngineFor(partner.BirthCountry)
+	divorceDate := prob.SampleDivorceDate(unionDate, partner.Age(unionDate), other.Age(unionDate))
+	if divorceDate != nil && bothAlive(*divorceDate, partner, other) && !b.personGen.beyondProjection(*divorceDate) {
+		family.DivorceDate = divorceDate
+		addPairEvents(model.EventDivorce, *divorceDate, partner, other)
+	}
 
-	marriageEventWife := model.NewLifeEvent(model.EventMarriage, marriageDate, wife.CurrentCountry).
-		WithRelatedID(husband.ID)
-	wife.Events = append(wife.Events, marriageEventWife)
+	eventType := model.EventMarriage
+	if family.UnionType == model.UnionCivilPartnership {
+		eventType = model.EventCivilUnion
+	} else if !family.SameSex {
+		b.personGen.applyMarriageName(partner, other, unionDate)
+	}
+	addPairEvents(eventType, unionDate, partner, other)
+}
 
-	return family
+func (b *FamilyBuilder) formCohabitation(family *model.Family, partner, other *model.Person) {
+	startDate := *family.UnionDate
+
+	partner.SpouseIDs = append(partner.SpouseIDs, other.ID)
+	other.SpouseIDs = append(other.SpouseIDs, partner.ID)
+
+	prob := b.personGen.GetProbabilityEngineFor(partner.BirthCountry)
+	separationDate := prob.SampleSeparationDate(startDate, partner.Age(startDate), other.Age(startDate))
+	if separationDate != nil && bothAlive(*separationDate, partner, other) && !b.personGen.beyondProjection(*separationDate) {
+		family.SeparationDate = separationDate
+		addPairEvents(model.EventSeparation, *separationDate, partner, other)
+	}
+
+	addPairEvents(model.EventCohabitation, startDate, partner, other)
 }
 
-func (b *FamilyBuilder) calculateMarriageYear(husband, wife *model.Person) int {
+func bothAlive(date time.Time, partner, other *model.Person) bool {
+	partnerAlive := partner.DeathDate == nil || partner.DeathDate.After(date)
+	otherAlive := other.DeathDate == nil || other.DeathDate.After(date)
+	return partnerAlive && otherAlive
+}
 
-	husbandMarriageAge := b.personGen.GetProbabilityEngine().CalculateMarriageAge(model.Male, husband.BirthDate.Year())
-	wifeMarriageAge := b.personGen.GetProbabilityEngine().CalculateMarriageAge(model.Female, wife.BirthDate.Year())
+func addPairEvents(eventType model.EventType, date time.Time, partner, other *model.Person) {
+	partnerEvent := model.NewLifeEvent(eventType, date, partner.CurrentCountry).
+		WithRelatedID(other.ID)
+	partner.Events = append(partner.Events, partnerEvent)
 
-	husbandMarriageYear := husband.BirthDate.Year() + husbandMarriageAge
-	wifeMarriageYear := wife.BirthDate.Year() + wifeMarriageAge
+	otherEvent := model.NewLifeEvent(eventType, date, other.CurrentCountry).
+		WithRelatedID(partner.ID)
+	other.Events = append(other.Events, otherEvent)
+}
 
-	marriageYear := husbandMarriageYear
-	if wifeMarriageYear > marriageYear {
-		marriageYear = wifeMarriageYear
+func unionEndDate(family *model.Family, partners ...*model.Person) *time.Time {
+	end := family.EndDate()
+	for _, partner := range partners {
+		if partner != nil && partner.DeathDate != nil && (end == nil || partner.DeathDate.Before(*end)) {
+			end = partner.DeathDate
+		}
 	}
+	return end
+}
+
+func (b *FamilyBuilder) calculateMarriageYear(partner, other *model.Person) int {
+	partnerProb := b.personGen.GetProbabilityEngineFor(partner.BirthCountry)
+	otherProb := b.personGen.GetProbabilityEngineFor(other.BirthCountry)
 
-	if husband.DeathDate != nil && marriageYear > husband.DeathDate.Year() {
-		marriageYear = husband.DeathDate.Year() - 1
+	partnerYear := partner.BirthDate.Year() + partnerProb.CalculateMarriageAge(partner.Gender, partner.BirthDate.Year())
+	otherYear := other.BirthDate.Year() + otherProb.CalculateMarriageAge(other.Gender, other.BirthDate.Year())
+	marriageYear := (partnerYear + otherYear + 1) / 2
+
+	for _, p := range []*model.Person{partner, other} {
+		if earliest := p.BirthDate.Year() + 18; marriageYear < earliest {
+			marriageYear = earliest
+		}
 	}
-	if wife.DeathDate != nil && marriageYear > wife.DeathDate.Year() {
-		marriageYear = wife.DeathDate.Year() - 1
+
+	for _, p := range []*model.Person{partner, other} {
+		if p.DeathDate != nil && marriageYear > p.DeathDate.Year() {
+			marriageYear = p.DeathDate.Year() - 1
+		}
 	}
 
 	return marriageYear
 }
 
-func (b *FamilyBuilder) GenerateChildren(family *model.Family, husband, wife *model.Person, tree *model.FamilyTree) []*model.Person {
-	prob := b.personGen.GetProbabilityEngine()
+func (b *FamilyBuilder) GenerateChildren(family *model.Family, father, mother *model.Person, tree *model.FamilyTree) []*model.Person {
+	prob := b.familyProb(father, mother)
 
-	numChildren := prob.CalculateChildrenCount(family.MarriedDate.Year())
+	familyYear := b.familyYear(family, father, mother)
+
+	numChildren := 0
+	if !prob.ShouldRemainChildless(familyYear) {
+		numChildren = prob.CalculateChildrenCount(familyYear)
+	}
+	if family.IsSingleParent() && numChildren > maxSingleParentChildren {
+		numChildren = maxSingleParentChildren
+	}
 
 	children := make([]*model.Person, 0, numChildren)
 
-	for i := 0; i < numChildren; i++ {
-		child := b.personGen.GenerateChild(husband, wife, i)
+	for _, birthDate := range b.childBirthDates(family, father, mother, numChildren, childrenBirthDates(tree, father, mother)) {
+		child := b.personGen.GenerateChild(father, mother, birthDate)
+		birthYear := birthDate.Year()
 
-		if child.BirthDate.Before(family.MarriedDate) {
+		if !parentsCanHaveChild(child, father, mother) || b.personGen.beyondProjection(child.BirthDate) || beforeUnion(family, child.BirthDate) {
+			continue
+		}
+		if end := family.EndDate(); end != nil && child.BirthDate.After(*end) {
+			continue
+		}
 
-			yearsAfterMarriage := b.rng.IntRange(1, 3) + i*b.rng.IntRange(2, 4)
-			child.BirthDate = family.MarriedDate.AddDate(yearsAfterMarriage, b.rng.IntRange(0, 11), b.rng.IntRange(1, 28))
+		babies := []*model.Person{child}
+		if mother != nil {
+			size, identical := prob.SampleMultipleBirth(birthYear, birthYear-mother.BirthDate.Year())
+			for i := 1; i < size; i++ {
+				babies = append(babies, b.personGen.GenerateTwin(child, father, mother, i < identical))
+			}
+			linkMultipleBirth(babies, identical)
 		}
 
-		if wife.DeathDate != nil && child.BirthDate.After(*wife.DeathDate) {
-			continue
+		for _, baby := range babies {
+			baby.BornOutsideMarriage = !family.MarriedBy(baby.BirthDate)
+			b.personGen.applyNamingTradition(baby, tree)
+
+			family.AddChild(baby.ID)
+			for _, parent := range []*model.Person{father, mother} {
+				if parent != nil {
+					parent.ChildrenIDs = append(parent.ChildrenIDs, baby.ID)
+					parent.NumberOfChildren++
+				}
+			}
+
+			tree.AddPerson(baby)
+			children = append(children, baby)
 		}
-		if husband.DeathDate != nil && child.BirthDate.After(*husband.DeathDate) {
-			continue
+	}
+
+	return children
+}
+
+func (b *FamilyBuilder) childBirthDates(family *model.Family, father, mother *model.Person, count int, taken []time.Time) []time.Time {
+	prob := b.familyProb(father, mother)
+
+	var motherBirthYear int
+	if mother != nil {
+		motherBirthYear = mother.BirthDate.Year()
+	} else {
+		motherBirthYear = father.BirthDate.Year() + b.rng.IntRange(2, 4)
+	}
+
+	minAge := 0
+	if family.UnionDate != nil {
+		minAge = family.UnionDate.Year() - motherBirthYear - premaritalBirthLeadYears
+	}
+
+	taken = append([]time.Time(nil), taken...)
+	dates := make([]time.Time, 0, count)
+	for _, age := range prob.SampleMotherAgesAtBirth(motherBirthYear, count, minAge) {
+		date := b.personGen.spacedBirthDate(b.personGen.generateBirthDate(motherBirthYear+age), taken)
+		taken = append(taken, date)
+		dates = append(dates, date)
+	}
+	return dates
+}
+
+func childrenBirthDates(tree *model.FamilyTree, father, mother *model.Person) []time.Time {
+	parent := mother
+	if parent == nil {
+		parent = father
+	}
+
+	dates := make([]time.Time, 0, len(parent.ChildrenIDs))
+	for _, id := range parent.ChildrenIDs {
+		if child := tree.GetPerson(id); child != nil {
+			dates = append(dates, child.BirthDate)
 		}
+	}
+	return dates
+}
+
+func beforeUnion(family *model.Family, date time.Time) bool {
+	return family.UnionDate != nil && date.Before(family.UnionDate.AddDate(-premaritalBirthLeadYears, 0, 0))
+}
 
-		motherAge := child.BirthDate.Year() - wife.BirthDate.Year()
+func (b *FamilyBuilder) familyYear(family *model.Family, father, mother *model.Person) int {
+	if family.UnionDate != nil {
+		return family.UnionDate.Year()
+	}
+
+	prob := b.familyProb(father, mother)
+	if mother != nil {
+		return mother.BirthDate.Year() + prob.CalculateMarriageAge(model.Female, mother.BirthDate.Year())
+	}
+	return father.BirthDate.Year() + prob.CalculateMarriageAge(model.Male, father.BirthDate.Year())
+}
+
+func (b *FamilyBuilder) familyProb(father, mother *model.Person) *ProbabilityEngine {
+	if mother != nil {
+		return b.personGen.GetProbabilityEngineFor(mother.BirthCountry)
+	}
+	return b.personGen.GetProbabilityEngineFor(father.BirthCountry)
+}
+
+func parentsCanHaveChild(child, father, mother *model.Person) bool {
+	if mother != nil {
+		if mother.DeathDate != nil && child.BirthDate.After(*mother.DeathDate) {
+			return false
+		}
+		motherAge := child.BirthDate.Year() - mother.BirthDate.Year()
 		if motherAge < minMotherAgeAtBirth || motherAge > maxMotherAgeAtBirth {
-			continue
+			return false
+		}
+	}
+	if father != nil {
+		if father.DeathDate != nil && child.BirthDate.After(*father.DeathDate) {
+			return false
 		}
-		fatherAge := child.BirthDate.Year() - husband.BirthDate.Year()
+		fatherAge := child.BirthDate.Year() - father.BirthDate.Year()
 		if fatherAge < minFatherAgeAtBirth || fatherAge > maxFatherAgeAtBirth {
-			continue
+			return false
+		}
+	}
+	return true
+}
+
+func (b *FamilyBuilder) GenerateAdoptedChildren(family *model.Family, partner, other *model.Person, tree *model.FamilyTree) []*model.Person {
+	prob := b.personGen.GetProbabilityEngineFor(partner.BirthCountry)
+	if family.UnionDate == nil {
+		return nil
+	}
+
+	numChildren := prob.CalculateAdoptionCount(family.UnionDate.Year())
+	children := make([]*model.Person, 0, numChildren)
+
+	for i := 0; i < numChildren; i++ {
+		adoptionDate := family.UnionDate.AddDate(b.rng.IntRange(1, 8)+i*b.rng.IntRange(1, 3), b.rng.IntRange(0, 11), b.rng.IntRange(1, 28))
+		if end := unionEndDate(family, partner, other); (end != nil && adoptionDate.After(*end)) || b.personGen.beyondProjection(adoptionDate) {
+			break
 		}
 
+		child := b.personGen.GenerateAdoptedChild(partner, other, adoptionDate)
+		child.Events = append(child.Events, model.NewLifeEvent(model.EventAdoption, adoptionDate, partner.CurrentCountry).WithRelatedID(family.ID))
+
 		family.AddChild(child.ID)
-		husband.ChildrenIDs = append(husband.ChildrenIDs, child.ID)
-		wife.ChildrenIDs = append(wife.ChildrenIDs, child.ID)
-		husband.NumberOfChildren++
-		wife.NumberOfChildren++
+		for _, parent := range []*model.Person{partner, other} {
+			child.AddParent(parent.ID, model.AdoptiveParent, &adoptionDate)
+			parent.ChildrenIDs = append(parent.ChildrenIDs, child.ID)
+			parent.NumberOfChildren++
+			parent.Events = append(parent.Events, model.NewLifeEvent(model.EventAdoption, adoptionDate, parent.CurrentCountry).WithRelatedID(child.ID))
+		}
 
 		tree.AddPerson(child)
 		children = append(children, child)
@@ -144,38 +339,94 @@ func (b *FamilyBuilder) GenerateChildren(family *model.Family, husband, wife *mo
 	return children
 }
 
-func (b *FamilyBuilder) LinkSpouses(husband, wife *model.Person, tree *model.FamilyTree) *model.Family {
-	family := b.CreateFamily(husband, wife)
+func (b *FamilyBuilder) LinkStepChildren(parent, stepParent *model.Person, since time.Time, tree *model.FamilyTree) {
+	for _, childID := range parent.ChildrenIDs {
+		child := tree.GetPerson(childID)
+		if child == nil || child.HasParent(stepParent.ID) || child.BirthDate.After(since) {
+			continue
+		}
+		if child.Age(since) >= legalAdultAge || (child.DeathDate != nil && child.DeathDate.Before(since)) {
+			continue
+		}
+
+		adoptionShare := stepParentAdoptionShare
+		if !hasOtherLivingParent(child, parent, since, tree) {
+			adoptionShare = absentParentAdoptionShare
+		}
+		if b.personGen.repo.InEarlyModernProfile(since.Year()) || !b.rng.Chance(adoptionShare) {
+			child.AddParent(stepParent.ID, model.StepParent, &since)
+			continue
+		}
+
+		adoptionDate := since.AddDate(b.rng.IntRange(0, 2), b.rng.IntRange(0, 11), b.rng.IntRange(0, 27))
+		if child.Age(adoptionDate) >= legalAdultAge || (stepParent.DeathDate != nil && stepParent.DeathDate.Before(adoptionDate)) {
+			child.AddParent(stepParent.ID, model.StepParent, &since)
+			continue
+		}
+
+		child.AddParent(stepParent.ID, model.AdoptiveParent, &adoptionDate)
+		child.Adopted = true
+		child.Events = append(child.Events, model.NewLifeEvent(model.EventAdoption, adoptionDate, child.CountryAt(adoptionDate)).WithRelatedID(stepParent.ID))
+		stepParent.ChildrenIDs = append(stepParent.ChildrenIDs, child.ID)
+		stepParent.NumberOfChildren++
+		stepParent.Events = append(stepParent.Events, model.NewLifeEvent(model.EventAdoption, adoptionDate, stepParent.CountryAt(adoptionDate)).WithRelatedID(child.ID))
+	}
+}
+
+func hasOtherLivingParent(child, parent *model.Person, at time.Time, tree *model.FamilyTree) bool {
+	for _, parentID := range child.ParentIDs(model.LegalLineage) {
+		if parentID == parent.ID {
+			continue
+		}
+		if other := tree.GetPerson(parentID); other != nil && (other.DeathDate == nil || other.DeathDate.After(at)) {
+			return true
+		}
+	}
+	return false
+}
+
+func (b *FamilyBuilder) LinkSpouses(partner, other *model.Person, unionType model.UnionType, tree *model.FamilyTree) *model.Family {
+	family := b.CreateFamily(partner, other, unionType)
+	tree.AddFamily(family)
+	return family
+}
+
+func (b *FamilyBuilder) LinkParents(father, mother, child *model.Person, unionType model.UnionType, tree *model.FamilyTree) *model.Family {
+	family := b.CreateParentsFamily(father, mother, child, unionType)
 	tree.AddFamily(family)
 	return family
 }
 
-func (b *FamilyBuilder) GenerateSiblings(person *model.Person, father, mother *model.Person, tree *model.FamilyTree) []*model.Person {
-	prob := b.personGen.GetProbabilityEngine()
+func (b *FamilyBuilder) LinkRemarriage(partner, other *model.Person, unionType model.UnionType, marriageDate time.Time, tree *model.FamilyTree) *model.Family {
+	family := b.CreateRemarriage(partner, other, unionType, marriageDate)
+	tree.AddFamily(family)
+	return family
+}
+
+func (b *FamilyBuilder) LinkSingleParent(parent *model.Person, tree *model.FamilyTree) *model.Family {
+	family := b.CreateSingleParentFamily(parent)
+	tree.AddFamily(family)
+	return family
+}
+
+func (b *FamilyBuilder) GenerateSiblings(family *model.Family, person *model.Person, father, mother *model.Person, tree *model.FamilyTree) []*model.Person {
+	prob := b.personGen.GetProbabilityEngineFor(person.BirthCountry)
 
 	numSiblings := prob.CalculateSiblingCount(person.BirthDate.Year())
 
 	siblings := make([]*model.Person, 0, numSiblings)
 
-	for i := 0; i < numSiblings; i++ {
-		sibling := b.personGen.GenerateSibling(person, father, mother, i)
+	for _, birthDate := range b.childBirthDates(family, father, mother, numSiblings, []time.Time{person.BirthDate}) {
+		sibling := b.personGen.GenerateSibling(person, father, mother, birthDate)
 
-		motherAge := sibling.BirthDate.Year() - mother.BirthDate.Year()
-		if motherAge < minMotherAgeAtBirth || motherAge > maxMotherAgeAtBirth {
-			continue
-		}
-		fatherAge := sibling.BirthDate.Year() - father.BirthDate.Year()
-		if fatherAge < minFatherAgeAtBirth || fatherAge > maxFatherAgeAtBirth {
+		if !parentsCanHaveChild(sibling, father, mother) || beforeUnion(family, sibling.BirthDate) {
 			continue
 		}
 
-		if mother.DeathDate != nil && sibling.BirthDate.After(*mother.DeathDate) {
-			continue
-		}
-		if father.DeathDate != nil && sibling.BirthDate.After(*father.DeathDate) {
-			continue
-		}
+		sibling.BornOutsideMarriage = !family.MarriedBy(sibling.BirthDate)
+		b.personGen.applyNamingTradition(sibling, tree)
 
+		family.AddChild(sibling.ID)
 		father.ChildrenIDs = append(father.ChildrenIDs, sibling.ID)
 		mother.ChildrenIDs = append(mother.ChildrenIDs, sibling.ID)
 
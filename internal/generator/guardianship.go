@@ -0,0 +1,87 @@
+package generator
+
+import (
+	"sort"
+	"time"
+
+	"github.com/familytree-generator/internal/model"
+)
+
+func (e *Engine) assignFosterCare() {
+	persons := e.tree.GetAllPersons()
+	sort.Slice(persons, func(i, j int) bool {
+		return persons[i].ID < persons[j].ID
+	})
+
+	for _, child := range persons {
+		orphaned := e.orphanedAt(child)
+		if orphaned == nil {
+			continue
+		}
+
+		guardian := e.findGuardian(child, *orphaned)
+		if guardian == nil {
+			continue
+		}
+
+		until := child.BirthDate.AddDate(legalAdultAge, 0, 0)
+		if child.DeathDate != nil && child.DeathDate.Before(until) {
+			until = *child.DeathDate
+		}
+		child.Parents = append(child.Parents, model.ParentLink{
+			ParentID:  guardian.ID,
+			Kind:      model.FosterParent,
+			StartDate: orphaned,
+			EndDate:   &until,
+		})
+	}
+}
+
+func (e *Engine) orphanedAt(child *model.Person) *time.Time {
+	if len(child.Parents) == 0 {
+		return nil
+	}
+
+	orphaned := child.BirthDate
+	for _, parentID := range child.ParentIDs(model.AllRelations) {
+		parent := e.tree.GetPerson(parentID)
+		if parent == nil || parent.DeathDate == nil {
+			return nil
+		}
+		if parent.DeathDate.After(orphaned) {
+			orphaned = *parent.DeathDate
+		}
+	}
+
+	if !child.AliveAt(orphaned) || child.Age(orphaned) >= legalAdultAge {
+		return nil
+	}
+	return &orphaned
+}
+
+func (e *Engine) findGuardian(child *model.Person, at time.Time) *model.Person {
+	parentIDs := child.ParentIDs(model.LegalLineage)
+
+	var candidates []*model.Person
+	for _, parentID := range parentIDs {
+		if parent := e.tree.GetPerson(parentID); parent != nil {
+			for _, grandparentID := range parent.ParentIDs(model.LegalLineage) {
+				candidates = append(candidates, e.tree.GetPerson(grandparentID))
+			}
+		}
+	}
+	for _, parentID := range parentIDs {
+		candidates = append(candidates, e.tree.GetSiblings(parentID)...)
+	}
+	candidates = append(candidates, e.tree.GetSiblings(child.ID)...)
+
+	for _, candidate := range candidates {
+		if candidate == nil || !candidate.AliveAt(at) {
+			continue
+		}
+		if age := candidate.Age(at); age >= minGuardianAge && age <= maxGuardianAge {
+			return candidate
+		}
+	}
+	return nil
+}
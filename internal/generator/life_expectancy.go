@@ -14,6 +14,6 @@ func ParseLifeExpectancyMode(value string) LifeExpectancyMode {
 	case LifeExpectancyFemale, LifeExpectancyMale, LifeExpectancyByGender, LifeExpectancyTotal:
 		return LifeExpectancyMode(value)
 	default:
-		return LifeExpectancyTotal
+		return LifeExpectancyByGender
 	}
 }
@@ -0,0 +1,234 @@
+package generator
+
+import "math"
+
+const (
+	netMigrationScale  = 10
+	emigrationAge      = 30
+	maxEmigrationShare = 0.5
+)
+
+type migrationCorridor struct {
+	origin      string
+	destination string
+	fromYear    int
+	toYear      int
+	weight      float64
+}
+
+var regionalAffinity = map[string]map[string]float64{
+	"Europe": {
+		"Europe":                6,
+		"North America":         3,
+		"Australia and Oceania": 1.5,
+		"South America":         1,
+		"Central Asia":          1,
+	},
+	"North America": {
+		"North America":                     5,
+		"Europe":                            2,
+		"Central America and the Caribbean": 1.5,
+		"Australia and Oceania":             1,
+	},
+	"Central America and the Caribbean": {
+		"North America":                     8,
+		"Central America and the Caribbean": 3,
+		"South America":                     1.5,
+		"Europe":                            1.5,
+	},
+	"South America": {
+		"South America":                     5,
+		"North America":                     3,
+		"Europe":                            3,
+		"Central America and the Caribbean": 1,
+	},
+	"Africa": {
+		"Africa":        6,
+		"Europe":        3,
+		"Middle East":   2,
+		"North America": 1,
+	},
+	"Middle East": {
+		"Middle East":   5,
+		"Europe":        3,
+		"North America": 1.5,
+	},
+	"South Asia": {
+		"Middle East":             6,
+		"South Asia":              3,
+		"Europe":                  2,
+		"North America":           2,
+		"East and Southeast Asia": 1,
+	},
+	"East and Southeast Asia": {
+		"East and Southeast Asia": 5,
+		"North America":           3,
+		"Australia and Oceania":   2,
+		"Europe":                  1,
+	},
+	"Central Asia": {
+		"Central Asia": 5,
+		"Europe":       4,
+		"Middle East":  1,
+	},
+	"Australia and Oceania": {
+		"Australia and Oceania": 6,
+		"Europe":                2,
+		"North America":         1.5,
+	},
+}
+
+var regionalCorridors = []migrationCorridor{
+	{"Europe", "North America", 1800, 1930, 3},
+	{"Europe", "South America", 1870, 1930, 3},
+	{"Europe", "Australia and Oceania", 1850, 1970, 2},
+	{"South Asia", "Middle East", 1800, 1970, 0.2},
+	{"Africa", "Europe", 1800, 1945, 0.3},
+}
+
+var countryCorridors = []migrationCorridor{
+	{"germany", "united-states", 1800, 1914, 8},
+	{"ireland", "united-states", 1845, 1930, 8},
+	{"ireland", "united-kingdom", 1800, 2100, 6},
+	{"italy", "united-states", 1880, 1924, 6},
+	{"italy", "argentina", 1870, 1930, 6},
+	{"spain", "argentina", 1870, 1930, 6},
+	{"portugal", "brazil", 1850, 1960, 6},
+	{"japan", "brazil", 1908, 1960, 4},
+	{"united-kingdom", "australia", 1850, 1970, 6},
+	{"united-kingdom", "canada", 1850, 1970, 5},
+	{"united-kingdom", "new-zealand", 1850, 1970, 4},
+	{"italy", "germany", 1955, 1975, 5},
+	{"turkey-turkiye", "germany", 1961, 2100, 10},
+	{"portugal", "france", 1960, 1975, 6},
+	{"algeria", "france", 1945, 2100, 8},
+	{"morocco", "france", 1960, 2100, 5},
+	{"tunisia", "france", 1960, 2100, 5},
+	{"morocco", "spain", 1990, 2100, 4},
+	{"india", "united-kingdom", 1948, 2100, 4},
+	{"pakistan", "united-kingdom", 1948, 2100, 5},
+	{"mexico", "united-states", 1940, 2100, 12},
+	{"cuba", "united-states", 1959, 2100, 10},
+	{"el-salvador", "united-states", 1980, 2100, 8},
+	{"guatemala", "united-states", 1980, 2100, 8},
+	{"honduras", "united-states", 1980, 2100, 8},
+	{"philippines", "united-states", 1946, 2100, 5},
+	{"vietnam", "united-states", 1975, 2100, 5},
+	{"korea-south", "united-states", 1965, 2100, 4},
+	{"china", "united-states", 1980, 2100, 3},
+	{"india", "united-arab-emirates", 1970, 2100, 6},
+	{"india", "united-states", 1965, 2100, 3},
+	{"poland", "germany", 1990, 2100, 6},
+	{"poland", "united-kingdom", 2004, 2100, 5},
+	{"romania", "italy", 2002, 2100, 6},
+	{"romania", "spain", 2002, 2100, 5},
+	{"ukraine", "russia", 1991, 2013, 6},
+	{"ukraine", "poland", 2014, 2100, 6},
+	{"kazakhstan", "russia", 1991, 2100, 8},
+	{"finland", "sweden", 1950, 1975, 8},
+	{"netherlands", "belgium", 1800, 2100, 3},
+	{"austria", "germany", 1800, 2100, 4},
+	{"germany", "switzerland", 1950, 2100, 3},
+	{"new-zealand", "australia", 1800, 2100, 8},
+	{"canada", "united-states", 1800, 2100, 6},
+	{"united-states", "canada", 1800, 2100, 3},
+}
+
+// emigrationChance is the lifetime chance that someone born in birthYear
+// emigrates. Emigrant stocks mostly hold working-age adults, so a cohort takes
+// the stock share of the year it turns 30. Before the first observed stock the
+// share is scaled by the corridors open from the origin then, relative to that
+// first year: a corridor adds its weight above one, or removes it below one.
+// Corridor weights describe where people go rather than how many leave, so the
+// ratio enters as a square root.
+func (p *ProbabilityEngine) emigrationChance(origin string, birthYear int) float64 {
+	year := birthYear + emigrationAge
+	firstYear, ok := p.repo.GetEmigrantsFirstYear(origin)
+	if !ok {
+		return 0
+	}
+	if year >= firstYear {
+		return math.Min(p.repo.GetEmigrantShare(origin, year), maxEmigrationShare)
+	}
+
+	region := p.repo.GetRegion(origin)
+	scale := math.Sqrt(emigrationPressure(origin, region, year) / emigrationPressure(origin, region, firstYear))
+	return math.Min(p.repo.GetEmigrantShare(origin, firstYear)*scale, maxEmigrationShare)
+}
+
+func emigrationPressure(origin, region string, year int) float64 {
+	pressure := 1.0
+	for _, c := range regionalCorridors {
+		if c.origin == region && year >= c.fromYear && year <= c.toYear {
+			pressure += c.weight - 1
+		}
+	}
+	for _, c := range countryCorridors {
+		if c.origin == origin && year >= c.fromYear && year <= c.toYear {
+			pressure += c.weight - 1
+		}
+	}
+	return pressure
+}
+
+func (g *PersonGenerator) chooseMigrationDestination(origin string, year int) string {
+	candidates := make([]string, 0, len(g.countryOptions))
+	weights := make([]float64, 0, len(g.countryOptions))
+	for _, candidate := range g.countryOptions {
+		if candidate == "" || candidate == origin {
+			continue
+		}
+		weight := g.migrationWeight(origin, candidate, year)
+		if weight <= 0 {
+			continue
+		}
+		candidates = append(candidates, candidate)
+		weights = append(weights, weight)
+	}
+	if len(candidates) == 0 {
+		return ""
+	}
+	return candidates[g.rng.WeightedChoice(weights)]
+}
+
+// migrationWeight scores a destination. total-number-of-emigrants.csv only
+// gives emigrant totals per origin, so destinations combine the affinity and
+// corridor tables with relative GDP per capita, population and the
+// destination's current net migration rate.
+func (g *PersonGenerator) migrationWeight(origin, destination string, year int) float64 {
+	population := g.repo.GetPopulationAt(destination, year)
+	if population <= 0 {
+		return 0
+	}
+
+	originRegion := g.repo.GetRegion(origin)
+	destinationRegion := g.repo.GetRegion(destination)
+
+	affinity := 0.5
+	if weight, ok := regionalAffinity[originRegion][destinationRegion]; ok {
+		affinity = weight
+	}
+	for _, c := range regionalCorridors {
+		if c.origin == originRegion && c.destination == destinationRegion && year >= c.fromYear && year <= c.toYear {
+			affinity *= c.weight
+		}
+	}
+	for _, c := range countryCorridors {
+		if c.origin == origin && c.destination == destination && year >= c.fromYear && year <= c.toYear {
+			affinity *= c.weight
+		}
+	}
+
+	prosperity := g.repo.GetGDPPerCapitaAt(destination, year) / math.Max(g.repo.GetGDPPerCapitaAt(origin, year), 1000)
+	if prosperity < 0.25 {
+		prosperity = 0.25
+	}
+	if prosperity > 4 {
+		prosperity = 4
+	}
+
+	pull := math.Exp(g.repo.GetMigrationRate(destination) / netMigrationScale)
+	pull = math.Max(0.25, math.Min(4, pull))
+
+	return affinity * prosperity * pull * math.Sqrt(population/1e6)
+}
@@ -0,0 +1,198 @@
+package generator
+
+import (
+	"math"
+
+	"github.com/familytree-generator/internal/model"
+)
+
+const (
+	lifeTableMaxAge = 110
+	youthAge        = 15
+
+	gompertzSlope    = 0.09
+	makehamHazard    = 0.0003
+	childHazardDecay = 1.2
+
+	mortalityReferenceYear     = 2024
+	mortalityHistoricFloorYear = 1850
+	mortalityFutureCapYear     = 2100
+	mortalityImprovementRate   = 0.012
+	adultImprovementShare      = 0.35
+	minMortalityImprovement    = 0.004
+	maxMortalityImprovement    = 0.02
+	cohortPeakDeathAge         = 65
+
+	tobaccoFrailty       = 2.0
+	heavyDrinkingFrailty = 1.3
+	heavyDrinkingLitres  = 10
+
+	survivalCutoff = 1e-4
+	daysPerYear    = 365.25
+)
+
+type lifeTableKey struct {
+	birthYear int
+	gender    model.Gender
+}
+
+type lifeTable struct {
+	child []float64
+	adult []float64
+}
+
+func newLifeTable(youthMortality, baseline, level float64) *lifeTable {
+	t := &lifeTable{
+		child: make([]float64, lifeTableMaxAge),
+		adult: make([]float64, lifeTableMaxAge),
+	}
+
+	var adultYouthHazard float64
+	for x := 0; x < lifeTableMaxAge; x++ {
+		gompertz := baseline * (math.Exp(gompertzSlope*float64(x+1)) - math.Exp(gompertzSlope*float64(x))) / gompertzSlope
+		t.adult[x] = level * (makehamHazard + gompertz)
+		if x < youthAge {
+			adultYouthHazard += t.adult[x]
+		}
+	}
+
+	childHazard := -math.Log(1-youthMortality) - adultYouthHazard
+	if childHazard <= 0 {
+		return t
+	}
+
+	scale := childHazard / (1 - math.Exp(-childHazardDecay*youthAge))
+	for x := 0; x < youthAge; x++ {
+		t.child[x] = scale * (math.Exp(-childHazardDecay*float64(x)) - math.Exp(-childHazardDecay*float64(x+1)))
+	}
+	return t
+}
+
+func (t *lifeTable) hazard(age int, frailty float64) float64 {
+	return t.child[age] + frailty*t.adult[age]
+}
+
+func (t *lifeTable) lifeExpectancy() float64 {
+	survival, total := 1.0, 0.0
+	for x := 0; x < lifeTableMaxAge; x++ {
+		next := survival * math.Exp(-t.hazard(x, 1))
+		total += (survival + next) / 2
+		survival = next
+	}
+	return total
+}
+
+func (t *lifeTable) sampleAge(u, frailty float64) float64 {
+	target := -math.Log(u)
+	var cumulative float64
+	for x := 0; x < lifeTableMaxAge; x++ {
+		h := t.hazard(x, frailty)
+		if cumulative+h >= target {
+			return float64(x) + (target-cumulative)/h
+		}
+		cumulative += h
+	}
+	return lifeTableMaxAge
+}
+
+func (t *lifeTable) survivalLimit() int {
+	var cumulative float64
+	for x := 0; x < lifeTableMaxAge; x++ {
+		cumulative += t.hazard(x, 1)
+		if math.Exp(-cumulative) < survivalCutoff {
+			return x + 1
+		}
+	}
+	return lifeTableMaxAge
+}
+
+func (p *ProbabilityEngine) SampleDeathAge(health model.HealthProfile, birthYear int, gender model.Gender) float64 {
+	frailty := 1.0
+	if health.TobaccoUse {
+		frailty *= tobaccoFrailty
+	}
+	if health.AlcoholConsumption > heavyDrinkingLitres {
+		frailty *= heavyDrinkingFrailty
+	}
+
+	u := p.rng.Float64()
+	for u == 0 {
+		u = p.rng.Float64()
+	}
+	return p.cohortLifeTable(birthYear, gender).sampleAge(u, frailty)
+}
+
+func (p *ProbabilityEngine) MaxAllowedAge(birthYear int, gender model.Gender) int {
+	maxAge := p.cohortLifeTable(birthYear, gender).survivalLimit()
+	if maxAge < 50 {
+		maxAge = 50
+	}
+	if maxAge > maxHumanAgeYears {
+		maxAge = maxHumanAgeYears
+	}
+	return maxAge
+}
+
+func (p *ProbabilityEngine) CohortLifeExpectancy(birthYear int, gender model.Gender) float64 {
+	return p.cohortLifeTable(birthYear, gender).lifeExpectancy()
+}
+
+func (p *ProbabilityEngine) cohortLifeTable(birthYear int, gender model.Gender) *lifeTable {
+	key := lifeTableKey{birthYear: birthYear, gender: gender}
+	if table, ok := p.lifeTables[key]; ok {
+		return table
+	}
+
+	youthMortality := p.repo.GetYouthMortality(p.country, birthYear) / 100
+	table := newLifeTable(youthMortality, p.gompertzBaseline(gender), p.mortalityLevel(birthYear+cohortPeakDeathAge))
+	p.lifeTables[key] = table
+	return table
+}
+
+func (p *ProbabilityEngine) gompertzBaseline(gender model.Gender) float64 {
+	if baseline, ok := p.gompertzBaselines[gender]; ok {
+		return baseline
+	}
+
+	target := p.baseLifeExpectancy(gender)
+	youthMortality := p.repo.GetYouthMortality(p.country, mortalityReferenceYear) / 100
+
+	low, high := 1e-8, 1e-1
+	for i := 0; i < 60; i++ {
+		mid := math.Sqrt(low * high)
+		if newLifeTable(youthMortality, mid, 1).lifeExpectancy() > target {
+			low = mid
+		} else {
+			high = mid
+		}
+	}
+
+	baseline := math.Sqrt(low * high)
+	p.gompertzBaselines[gender] = baseline
+	return baseline
+}
+
+func (p *ProbabilityEngine) mortalityLevel(periodYear int) float64 {
+	if periodYear < mortalityHistoricFloorYear {
+		periodYear = mortalityHistoricFloorYear
+	}
+	if periodYear > mortalityFutureCapYear {
+		periodYear = mortalityFutureCapYear
+	}
+	if periodYear <= mortalityReferenceYear {
+		return math.Exp(p.mortalityImprovement() * float64(mortalityReferenceYear-periodYear))
+	}
+	return math.Exp(-p.repo.GetMortalityImprovementRate() * float64(periodYear-mortalityReferenceYear))
+}
+
+// mortalityImprovement is the yearly decline of adult mortality before the
+// reference year. Only today's life expectancy is bundled, so the trend comes
+// from the country's under-15 mortality series: adult mortality is assumed to
+// fall at a fixed share of the fitted youth decline.
+func (p *ProbabilityEngine) mortalityImprovement() float64 {
+	decline, ok := p.repo.GetYouthMortalityDecline(p.country)
+	if !ok {
+		return mortalityImprovementRate
+	}
+	return math.Max(minMortalityImprovement, math.Min(maxMortalityImprovement, decline*adultImprovementShare))
+}
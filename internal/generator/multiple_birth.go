@@ -0,0 +1,104 @@
+package generator
+
+import "github.com/familytree-generator/internal/model"
+
+const (
+	monozygoticTwinRate   = 0.004
+	defaultDizygoticRate  = 0.007
+	identicalTripletShare = 0.3
+
+	assistedReproductionYear   = 1980
+	assistedReproductionPeak   = 2005
+	singleEmbryoTransferYear   = 2015
+	assistedReproductionBoost  = 0.8
+	singleEmbryoTransferBoost  = 0.4
+	assistedReproductionMinGDP = 20000
+)
+
+var dizygoticTwinRates = map[string]float64{
+	"Africa":                            0.018,
+	"Europe":                            0.008,
+	"North America":                     0.0085,
+	"Australia and Oceania":             0.0085,
+	"Middle East":                       0.008,
+	"Central Asia":                      0.007,
+	"South Asia":                        0.006,
+	"South America":                     0.006,
+	"Central America and the Caribbean": 0.006,
+	"East and Southeast Asia":           0.004,
+}
+
+func (p *ProbabilityEngine) SampleMultipleBirth(year, motherAge int) (size, identical int) {
+	boost := p.assistedReproductionBoost(year)
+
+	fraternal := p.dizygoticRate() * maternalTwinFactor(motherAge) * (1 + boost)
+	triplets := (fraternal + monozygoticTwinRate) * fraternal * (1 + 2*boost)
+
+	u := p.rng.Float64()
+	switch {
+	case u < triplets:
+		if p.rng.Chance(identicalTripletShare) {
+			return 3, 2
+		}
+		return 3, 0
+	case u < triplets+monozygoticTwinRate:
+		return 2, 2
+	case u < triplets+monozygoticTwinRate+fraternal:
+		return 2, 0
+	}
+	return 1, 0
+}
+
+func (p *ProbabilityEngine) dizygoticRate() float64 {
+	if rate, ok := dizygoticTwinRates[p.repo.GetRegion(p.country)]; ok {
+		return rate
+	}
+	return defaultDizygoticRate
+}
+
+func (p *ProbabilityEngine) assistedReproductionBoost(year int) float64 {
+	if year < assistedReproductionYear || p.repo.GetGDPPerCapitaAt(p.country, year) < assistedReproductionMinGDP {
+		return 0
+	}
+	if year <= assistedReproductionPeak {
+		return assistedReproductionBoost * float64(year-assistedReproductionYear) / float64(assistedReproductionPeak-assistedReproductionYear)
+	}
+	if year >= singleEmbryoTransferYear {
+		return singleEmbryoTransferBoost
+	}
+	share := float64(year-assistedReproductionPeak) / float64(singleEmbryoTransferYear-assistedReproductionPeak)
+	return assistedReproductionBoost + share*(singleEmbryoTransferBoost-assistedReproductionBoost)
+}
+
+func maternalTwinFactor(motherAge int) float64 {
+	switch {
+	case motherAge < 20:
+		return 0.6
+	case motherAge < 25:
+		return 0.8
+	case motherAge < 30:
+		return 1.0
+	case motherAge < 35:
+		return 1.3
+	case motherAge < 40:
+		return 1.6
+	}
+	return 1.2
+}
+
+func linkMultipleBirth(babies []*model.Person, identical int) {
+	if len(babies) < 2 {
+		return
+	}
+	for i, baby := range babies {
+		baby.Zygosity = model.Fraternal
+		if i < identical {
+			baby.Zygosity = model.Identical
+		}
+		for _, twin := range babies {
+			if twin != baby {
+				baby.TwinIDs = append(baby.TwinIDs, twin.ID)
+			}
+		}
+	}
+}
@@ -0,0 +1,797 @@
+package generator
+
+import (
+	"math"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/familytree-generator/internal/model"
+	"github.com/familytree-generator/pkg/rand"
+)
+
+type surnameScheme int
+
+const (
+	surnameSingle surnameScheme = iota
+	surnameDouble
+	surnameDoubleMaternalFirst
+	surnamePatronymic
+)
+
+type patronymicScheme int
+
+const (
+	patronymicNone patronymicScheme = iota
+	patronymicRussian
+	patronymicUkrainian
+)
+
+type genderedScheme int
+
+const (
+	genderedNone genderedScheme = iota
+	genderedEastSlavic
+	genderedPolish
+	genderedCzech
+	genderedLithuanian
+	genderedLatvian
+	genderedGreek
+)
+
+type namesakeTradition int
+
+const (
+	namesakeNone namesakeTradition = iota
+	namesakeSuffix
+	namesakeGrandparent
+)
+
+const (
+	defaultWifeTakesName = 0.85
+	matronymicShare      = 0.04
+
+	juniorShare            = 0.05
+	grandparentNamingShare = 0.75
+	ancestorNamesakeShare  = 0.06
+	ancestorGivenNameShare = 0.4
+	defaultHostNameShare   = 0.5
+	laterCallNameShare     = 0.15
+	maxNameAttempts        = 20
+)
+
+var generationalSuffixes = []string{"", "Jr.", "III", "IV", "V"}
+
+var givenNameCounts = map[string][]float64{
+	"germany":        {0.5, 0.4, 0.1},
+	"austria":        {0.5, 0.4, 0.1},
+	"switzerland":    {0.5, 0.4, 0.1},
+	"netherlands":    {0.35, 0.4, 0.25},
+	"belgium":        {0.35, 0.4, 0.25},
+	"france":         {0.3, 0.4, 0.3},
+	"sweden":         {0.15, 0.6, 0.25},
+	"norway":         {0.2, 0.65, 0.15},
+	"denmark":        {0.2, 0.6, 0.2},
+	"finland":        {0.1, 0.6, 0.3},
+	"iceland":        {0.5, 0.5},
+	"portugal":       {0.2, 0.7, 0.1},
+	"brazil":         {0.5, 0.45, 0.05},
+	"spain":          {0.75, 0.25},
+	"italy":          {0.8, 0.2},
+	"poland":         {0.6, 0.4},
+	"czechia":        {0.9, 0.1},
+	"united-states":  {0.1, 0.85, 0.05},
+	"canada":         {0.15, 0.75, 0.1},
+	"united-kingdom": {0.2, 0.7, 0.1},
+	"ireland":        {0.25, 0.7, 0.05},
+	"australia":      {0.15, 0.75, 0.1},
+	"new-zealand":    {0.15, 0.75, 0.1},
+	"philippines":    {0.4, 0.6},
+}
+
+var nameRegionShares = map[string]map[string]float64{
+	"united-kingdom": {"England": 0.84, "Scotland": 0.08, "Wales": 0.05, "Northern Ireland": 0.03},
+	"spain":          {"Excluding Basque Country & Catalonia": 0.79, "Catalonia": 0.16, "Basque Country": 0.05},
+}
+
+type namingCustom struct {
+	surnames          surnameScheme
+	patronymic        patronymicScheme
+	gendered          genderedScheme
+	namesakes         namesakeTradition
+	wifeTakesName     float64
+	wifeKeepsNameFrom int
+}
+
+var (
+	spanishNaming    = namingCustom{surnames: surnameDouble}
+	portugueseNaming = namingCustom{surnames: surnameDoubleMaternalFirst, wifeTakesName: 0.5}
+	russianNaming    = namingCustom{patronymic: patronymicRussian, gendered: genderedEastSlavic, wifeTakesName: 0.85}
+	keepNameNaming   = namingCustom{}
+)
+
+var namingCustoms = map[string]namingCustom{
+	"iceland":            {surnames: surnamePatronymic},
+	"russia":             russianNaming,
+	"belarus":            russianNaming,
+	"kazakhstan":         russianNaming,
+	"ukraine":            {patronymic: patronymicUkrainian, gendered: genderedEastSlavic, wifeTakesName: 0.85},
+	"bulgaria":           {gendered: genderedEastSlavic, wifeTakesName: 0.85},
+	"poland":             {gendered: genderedPolish, wifeTakesName: 0.9},
+	"czechia":            {gendered: genderedCzech, wifeTakesName: 0.95},
+	"slovakia":           {gendered: genderedCzech, wifeTakesName: 0.95},
+	"lithuania":          {gendered: genderedLithuanian, wifeTakesName: 0.9},
+	"latvia":             {gendered: genderedLatvian, wifeTakesName: 0.9},
+	"greece":             {gendered: genderedGreek, namesakes: namesakeGrandparent, wifeTakesName: 0.9, wifeKeepsNameFrom: 1983},
+	"cyprus":             {namesakes: namesakeGrandparent, wifeTakesName: 0.9},
+	"spain":              spanishNaming,
+	"mexico":             spanishNaming,
+	"argentina":          spanishNaming,
+	"bolivia":            spanishNaming,
+	"chile":              spanishNaming,
+	"colombia":           spanishNaming,
+	"costa-rica":         spanishNaming,
+	"cuba":               spanishNaming,
+	"dominican-republic": spanishNaming,
+	"ecuador":            spanishNaming,
+	"el-salvador":        spanishNaming,
+	"guatemala":          spanishNaming,
+	"honduras":           spanishNaming,
+	"nicaragua":          spanishNaming,
+	"panama":             spanishNaming,
+	"paraguay":           spanishNaming,
+	"peru":               spanishNaming,
+	"uruguay":            spanishNaming,
+	"venezuela":          spanishNaming,
+	"portugal":           portugueseNaming,
+	"brazil":             portugueseNaming,
+	"italy":              {namesakes: namesakeGrandparent},
+	"china":              keepNameNaming,
+	"taiwan":             keepNameNaming,
+	"vietnam":            keepNameNaming,
+	"korea-south":        keepNameNaming,
+	"japan":              {wifeTakesName: 0.96},
+	"united-states":      {namesakes: namesakeSuffix, wifeTakesName: defaultWifeTakesName},
+	"philippines":        {namesakes: namesakeSuffix, wifeTakesName: defaultWifeTakesName},
+}
+
+func namingCustomFor(country string) namingCustom {
+	if custom, ok := namingCustoms[country]; ok {
+		return custom
+	}
+	return namingCustom{wifeTakesName: defaultWifeTakesName}
+}
+
+func (c namingCustom) usesGenderedSurnames() bool {
+	return c.gendered != genderedNone
+}
+
+func (g *PersonGenerator) useNameRegion(requested string) string {
+	g.region = requested
+	regions := g.repo.GetNameRegions(g.country)
+	for _, region := range regions {
+		if strings.EqualFold(region, requested) {
+			g.region = region
+		}
+	}
+
+	if requested == "" && !g.repo.HasNationalForenames(g.country) {
+		if len(regions) > 0 {
+			weights := make([]float64, len(regions))
+			for i, region := range regions {
+				weights[i] = 1
+				if share, ok := nameRegionShares[g.country][region]; ok {
+					weights[i] = share
+				}
+			}
+			g.region = regions[g.rng.WeightedChoice(weights)]
+		}
+	}
+	return g.region
+}
+
+func (g *PersonGenerator) generateSurnames(custom namingCustom, country string) []string {
+	if custom.surnames == surnameDouble || custom.surnames == surnameDoubleMaternalFirst {
+		return []string{g.generateLastName(country), g.generateLastName(country)}
+	}
+	return []string{g.generateLastName(country)}
+}
+
+func heritageCountry(country string, father, mother *model.Person) string {
+	for _, parent := range []*model.Person{father, mother} {
+		if parent != nil && parent.BirthCountry != country {
+			return parent.BirthCountry
+		}
+	}
+	return country
+}
+
+func (g *PersonGenerator) heritageOf(person *model.Person) string {
+	if heritage, ok := g.heritage[person.ID]; ok {
+		return heritage
+	}
+	return person.BirthCountry
+}
+
+func (g *PersonGenerator) nameCountry(person *model.Person) string {
+	return g.pickNameCountry(person.BirthCountry, g.heritageOf(person), person.Gender)
+}
+
+func (g *PersonGenerator) pickNameCountry(country, heritage string, gender model.Gender) string {
+	if heritage == country || len(g.repo.GetForenamesByGender(heritage, string(gender))) == 0 {
+		return country
+	}
+	if len(g.repo.GetForenamesByGender(country, string(gender))) == 0 || !g.rng.Chance(g.hostNameShare) {
+		return heritage
+	}
+	return country
+}
+
+func (g *PersonGenerator) useHostNameShare(share float64) {
+	g.hostNameShare = math.Max(0, math.Min(1, share))
+}
+
+func (g *PersonGenerator) localizedForename(person *model.Person, name string) string {
+	if native := g.repo.GetLocalizedForename(g.heritageOf(person), name); native != "" {
+		return native
+	}
+	return g.repo.GetLocalizedForename(person.BirthCountry, name)
+}
+
+func (g *PersonGenerator) familySurnames(person *model.Person) []string {
+	if surnames, ok := g.familyNames[person.ID]; ok {
+		return surnames
+	}
+	return []string{person.LastName}
+}
+
+func (g *PersonGenerator) inheritedSurnames(custom namingCustom, father, mother *model.Person) []string {
+	if father == nil && mother == nil {
+		return nil
+	}
+	if father == nil {
+		return g.familySurnames(mother)
+	}
+	if mother == nil {
+		return g.familySurnames(father)
+	}
+
+	paternal := g.familySurnames(father)
+	maternal := g.familySurnames(mother)
+	switch custom.surnames {
+	case surnameDouble:
+		return []string{paternal[0], maternal[0]}
+	case surnameDoubleMaternalFirst:
+		return []string{maternal[len(maternal)-1], paternal[len(paternal)-1]}
+	default:
+		return paternal
+	}
+}
+
+func adoptiveParents(partner, other *model.Person) (*model.Person, *model.Person) {
+	var father, mother *model.Person
+	for _, parent := range []*model.Person{partner, other} {
+		if parent == nil {
+			continue
+		}
+		if parent.Gender == model.Male && father == nil {
+			father = parent
+		} else if parent.Gender == model.Female && mother == nil {
+			mother = parent
+		}
+	}
+	return father, mother
+}
+
+func (g *PersonGenerator) inheritNames(child, father, mother *model.Person) {
+	custom := namingCustomFor(child.BirthCountry)
+	g.setBirthNames(child, g.inheritedSurnames(custom, father, mother), father, mother)
+}
+
+func (g *PersonGenerator) setBirthNames(person *model.Person, surnames []string, father, mother *model.Person) {
+	custom := namingCustomFor(person.BirthCountry)
+	if len(surnames) == 0 {
+		surnames = g.generateSurnames(custom, g.heritageOf(person))
+	}
+	g.familyNames[person.ID] = surnames
+
+	fatherName := ""
+	if father != nil {
+		fatherName = father.FirstName
+	} else if custom.patronymic != patronymicNone || (custom.surnames == surnamePatronymic && mother == nil) {
+		fatherName = g.generateFirstName(g.heritageOf(person), model.Male, person.BirthDate.Year()-30)
+	}
+
+	nativeBirthName := ""
+	switch custom.surnames {
+	case surnamePatronymic:
+		if mother != nil && (father == nil || g.rng.Chance(matronymicShare)) {
+			person.BirthName = icelandicPatronymic(mother.FirstName, mother.Gender, person.Gender)
+		} else {
+			person.BirthName = icelandicPatronymic(fatherName, model.Male, person.Gender)
+		}
+	default:
+		person.BirthName = genderedSurname(custom.gendered, strings.Join(surnames, " "), person.Gender, false)
+		nativeBirthName = g.nativeSurname(custom, g.heritageOf(person), surnames, person.Gender, false)
+	}
+
+	switch custom.patronymic {
+	case patronymicRussian:
+		person.Patronymic = russianPatronymic(fatherName, person.Gender)
+		person.NativePatronymic = cyrillicPatronymic(g.localizedForename(person, fatherName), person.Gender)
+	case patronymicUkrainian:
+		person.Patronymic = ukrainianPatronymic(fatherName, person.Gender)
+		person.NativePatronymic = ukrainianCyrillicPatronymic(g.localizedForename(person, fatherName), person.Gender)
+	}
+
+	if person.MarriedName == "" {
+		person.LastName = person.BirthName
+		person.NativeLastName = nativeBirthName
+	}
+	g.setNativeFirstName(person)
+}
+
+func (g *PersonGenerator) setNativeFirstName(person *model.Person) {
+	person.NativeFirstName = g.localizedForename(person, person.FirstName)
+	person.NameScript = nameScript(g.heritageOf(person), person.NativeFirstName+person.NativePatronymic+person.NativeLastName)
+}
+
+func (g *PersonGenerator) nativeSurname(custom namingCustom, country string, surnames []string, gender model.Gender, married bool) string {
+	native := make([]string, len(surnames))
+	for i, surname := range surnames {
+		native[i] = g.repo.GetLocalizedSurname(country, surname)
+		if native[i] == "" {
+			return ""
+		}
+	}
+	return genderedSurname(custom.gendered, strings.Join(native, " "), gender, married)
+}
+
+func nameScript(country, native string) string {
+	var han, kana, hangul bool
+	for _, r := range native {
+		switch {
+		case unicode.Is(unicode.Han, r):
+			han = true
+		case unicode.In(r, unicode.Hiragana, unicode.Katakana):
+			kana = true
+		case unicode.Is(unicode.Hangul, r):
+			hangul = true
+		case unicode.Is(unicode.Cyrillic, r):
+			return "Cyrl"
+		case unicode.Is(unicode.Greek, r):
+			return "Grek"
+		case unicode.Is(unicode.Armenian, r):
+			return "Armn"
+		case unicode.Is(unicode.Georgian, r):
+			return "Geor"
+		case unicode.Is(unicode.Hebrew, r):
+			return "Hebr"
+		case unicode.Is(unicode.Arabic, r):
+			return "Arab"
+		case unicode.Is(unicode.Devanagari, r):
+			return "Deva"
+		case unicode.Is(unicode.Bengali, r):
+			return "Beng"
+		case unicode.Is(unicode.Thai, r):
+			return "Thai"
+		}
+	}
+
+	switch {
+	case kana || (han && country == "japan"):
+		return "Jpan"
+	case hangul && han:
+		return "Kore"
+	case hangul:
+		return "Hang"
+	case han && country == "china":
+		return "Hans"
+	case han && country == "taiwan":
+		return "Hant"
+	case han:
+		return "Hani"
+	case native != "":
+		return "Latn"
+	}
+	return ""
+}
+
+func (g *PersonGenerator) applyNamingTradition(child *model.Person, tree *model.FamilyTree) {
+	father := treePerson(tree, child.FatherID)
+	mother := treePerson(tree, child.MotherID)
+	taken := livingSiblingNames(child, tree, father, mother)
+
+	grandparents := make([]*model.Person, 0, 2)
+	for _, parent := range []*model.Person{father, mother} {
+		if parent == nil {
+			continue
+		}
+		grandparentID := parent.MotherID
+		if child.Gender == model.Male {
+			grandparentID = parent.FatherID
+		}
+		if grandparent := treePerson(tree, grandparentID); grandparent != nil {
+			grandparents = append(grandparents, grandparent)
+		}
+	}
+
+	ancestors := sameGender(child.Gender, append([]*model.Person{father, mother}, grandparents...)...)
+
+	if namesake := g.parishNamesake(child, tree, father, mother, taken); namesake != nil {
+		child.FirstName = namesake.FirstName
+		child.NamesakeID = &namesake.ID
+		g.assignGivenNames(child, ancestors)
+		g.setNativeFirstName(child)
+		return
+	}
+
+	custom := namingCustomFor(child.BirthCountry)
+	if namesake := g.chooseNamesake(custom, child, father, grandparents, ancestors, taken); namesake != nil {
+		child.FirstName = namesake.FirstName
+		child.NamesakeID = &namesake.ID
+		g.assignGivenNames(child, ancestors)
+		if custom.namesakes == namesakeSuffix {
+			child.Suffix = generationalSuffix(child, namesake, father)
+			if child.Suffix != "" && len(namesake.GivenNames) > 0 {
+				child.GivenNames = append([]string(nil), namesake.GivenNames...)
+				child.CallName = namesake.CallName
+			}
+		}
+		g.setNativeFirstName(child)
+		return
+	}
+
+	for attempt := 0; taken[child.FirstName] && attempt < maxNameAttempts; attempt++ {
+		child.FirstName = g.generateFirstName(g.nameCountry(child), child.Gender, child.BirthDate.Year())
+	}
+	g.assignGivenNames(child, ancestors)
+	g.setNativeFirstName(child)
+}
+
+func (g *PersonGenerator) assignGivenNames(person *model.Person, ancestors []*model.Person) {
+	count := 1
+	if shares, ok := givenNameCounts[person.BirthCountry]; ok {
+		count = g.rng.WeightedChoice(shares) + 1
+	}
+
+	names := []string{person.FirstName}
+	used := map[string]bool{person.FirstName: true}
+	for attempt := 0; len(names) < count && attempt < maxNameAttempts; attempt++ {
+		var name string
+		candidates := make([]string, 0, len(ancestors))
+		for _, ancestor := range ancestors {
+			if !used[ancestor.FirstName] {
+				candidates = append(candidates, ancestor.FirstName)
+			}
+		}
+		if len(candidates) > 0 && g.rng.Chance(ancestorGivenNameShare) {
+			name = rand.Choice(g.rng, candidates)
+		} else {
+			name = g.generateFirstName(g.nameCountry(person), person.Gender, person.BirthDate.Year())
+		}
+		if !used[name] {
+			used[name] = true
+			names = append(names, name)
+		}
+	}
+
+	person.GivenNames = names
+	person.CallName = names[0]
+	if len(names) > 1 && g.rng.Chance(laterCallNameShare) {
+		person.CallName = names[g.rng.IntRange(1, len(names)-1)]
+	}
+}
+
+func (g *PersonGenerator) chooseNamesake(custom namingCustom, child, father *model.Person, grandparents, ancestors []*model.Person, taken map[string]bool) *model.Person {
+	switch custom.namesakes {
+	case namesakeGrandparent:
+		if g.rng.Chance(grandparentNamingShare) {
+			for _, grandparent := range grandparents {
+				if !taken[grandparent.FirstName] {
+					return grandparent
+				}
+			}
+		}
+	case namesakeSuffix:
+		if child.Gender == model.Male && father != nil && !taken[father.FirstName] && g.rng.Chance(juniorShare) {
+			return father
+		}
+	}
+
+	if !g.rng.Chance(ancestorNamesakeShare) {
+		return nil
+	}
+
+	candidates := make([]*model.Person, 0, len(ancestors))
+	for _, ancestor := range ancestors {
+		if !taken[ancestor.FirstName] {
+			candidates = append(candidates, ancestor)
+		}
+	}
+	if len(candidates) == 0 {
+		return nil
+	}
+	return rand.Choice(g.rng, candidates)
+}
+
+func generationalSuffix(child, namesake, father *model.Person) string {
+	if child.Gender != model.Male || child.BirthName != namesake.BirthName {
+		return ""
+	}
+	if namesake != father {
+		if namesake.Suffix == "" {
+			return "II"
+		}
+		return ""
+	}
+	if father.Suffix == "II" {
+		return "III"
+	}
+	for i, suffix := range generationalSuffixes[:len(generationalSuffixes)-1] {
+		if suffix == father.Suffix {
+			return generationalSuffixes[i+1]
+		}
+	}
+	return ""
+}
+
+func livingSiblingNames(child *model.Person, tree *model.FamilyTree, father, mother *model.Person) map[string]bool {
+	names := make(map[string]bool)
+	for _, parent := range []*model.Person{father, mother} {
+		if parent == nil {
+			continue
+		}
+		for _, id := range parent.ChildrenIDs {
+			sibling := tree.GetPerson(id)
+			if sibling == nil || sibling.ID == child.ID {
+				continue
+			}
+			if sibling.DeathDate != nil && sibling.DeathDate.Before(child.BirthDate) {
+				continue
+			}
+			names[sibling.FirstName] = true
+		}
+	}
+	return names
+}
+
+func sameGender(gender model.Gender, persons ...*model.Person) []*model.Person {
+	matching := make([]*model.Person, 0, len(persons))
+	for _, p := range persons {
+		if p != nil && p.Gender == gender {
+			matching = append(matching, p)
+		}
+	}
+	return matching
+}
+
+func treePerson(tree *model.FamilyTree, id *string) *model.Person {
+	if id == nil {
+		return nil
+	}
+	return tree.GetPerson(*id)
+}
+
+func (g *PersonGenerator) applyMarriageName(partner, other *model.Person, marriageDate time.Time) {
+	husband, wife := parentsByGender(partner, other)
+	if husband.Gender == wife.Gender {
+		return
+	}
+
+	custom := namingCustomFor(wife.BirthCountry)
+	if custom.wifeKeepsNameFrom > 0 && marriageDate.Year() >= custom.wifeKeepsNameFrom {
+		return
+	}
+	if !g.rng.Chance(custom.wifeTakesName) {
+		return
+	}
+
+	surnames := g.familySurnames(husband)
+	if custom.surnames == surnameDoubleMaternalFirst {
+		surnames = []string{g.familySurnames(wife)[0], surnames[len(surnames)-1]}
+	}
+
+	wife.MarriedName = genderedSurname(custom.gendered, strings.Join(surnames, " "), model.Female, true)
+	wife.LastName = wife.MarriedName
+	wife.NativeLastName = g.nativeSurname(custom, g.heritageOf(husband), surnames, model.Female, true)
+	g.setNativeFirstName(wife)
+}
+
+func genderedSurname(scheme genderedScheme, surname string, gender model.Gender, married bool) string {
+	if gender != model.Female {
+		return surname
+	}
+
+	var rules [][2]string
+	switch scheme {
+	case genderedEastSlavic:
+		rules = [][2]string{
+			{"skiy", "skaya"}, {"skii", "skaya"}, {"sky", "skaya"}, {"skyi", "ska"}, {"skyy", "ska"},
+			{"ski", "skaja"}, {"oy", "aya"}, {"oŭ", "ova"}, {"eŭ", "eva"},
+			{"ov", "ova"}, {"ev", "eva"}, {"in", "ina"}, {"yn", "yna"},
+			{"ский", "ская"}, {"цкий", "цкая"}, {"ський", "ська"}, {"ой", "ая"}, {"оў", "ова"}, {"еў", "ева"},
+			{"ов", "ова"}, {"ев", "ева"}, {"ёв", "ёва"}, {"ин", "ина"}, {"ын", "ына"},
+		}
+	case genderedPolish:
+		rules = [][2]string{{"dzki", "dzka"}, {"cki", "cka"}, {"ski", "ska"}}
+	case genderedCzech:
+		rules = [][2]string{{"ová", "ová"}, {"á", "á"}, {"ý", "á"}, {"ek", "ková"}, {"ec", "cová"}, {"a", "ová"}, {"", "ová"}}
+	case genderedLithuanian:
+		if married {
+			rules = [][2]string{{"ius", "ienė"}, {"as", "ienė"}, {"ys", "ienė"}, {"is", "ienė"}, {"us", "uvienė"}}
+		} else {
+			rules = [][2]string{{"ius", "iūtė"}, {"as", "aitė"}, {"ys", "ytė"}, {"is", "ytė"}, {"us", "utė"}}
+		}
+	case genderedLatvian:
+		rules = [][2]string{{"ons", "one"}, {"š", "a"}, {"is", "e"}, {"s", "a"}}
+	case genderedGreek:
+		rules = [][2]string{
+			{"os", "ou"}, {"is", "i"}, {"as", "a"},
+			{"όπουλος", "οπούλου"}, {"ος", "ου"}, {"ός", "ού"}, {"ης", "η"}, {"ής", "ή"}, {"ας", "α"}, {"άς", "ά"},
+		}
+	default:
+		return surname
+	}
+
+	for _, rule := range rules {
+		if strings.HasSuffix(surname, rule[0]) {
+			return strings.TrimSuffix(surname, rule[0]) + rule[1]
+		}
+	}
+	return surname
+}
+
+func russianPatronymic(fatherName string, gender model.Gender) string {
+	male, female := "ovich", "ovna"
+	stem := fatherName
+
+	switch {
+	case fatherName == "":
+		return ""
+	case fatherName == "Pavel":
+		stem = "Pavl"
+	case fatherName == "Pyotr":
+		stem = "Petr"
+	case fatherName == "Lev":
+		stem = "Lv"
+	case fatherName == "Alexander" || fatherName == "Aleksandr":
+		stem = "Aleksandr"
+	case fatherName == "Igor":
+		male, female = "evich", "evna"
+	case strings.HasSuffix(fatherName, "ya"):
+		stem = strings.TrimSuffix(fatherName, "ya")
+		male, female = "ich", "inichna"
+	case strings.HasSuffix(fatherName, "a"):
+		stem = strings.TrimSuffix(fatherName, "a")
+		male, female = "ich", "ichna"
+	case strings.HasSuffix(fatherName, "y") || strings.HasSuffix(fatherName, "i"):
+		stem = fatherName[:len(fatherName)-1]
+		male, female = "yevich", "yevna"
+		if !strings.ContainsAny(stem[len(stem)-1:], "aeiou") {
+			male, female = "iyevich", "iyevna"
+		}
+	}
+
+	if gender == model.Female {
+		return stem + female
+	}
+	return stem + male
+}
+
+func cyrillicPatronymic(fatherName string, gender model.Gender) string {
+	male, female := "ович", "овна"
+	stem := fatherName
+
+	switch {
+	case fatherName == "":
+		return ""
+	case fatherName == "Павел":
+		stem = "Павл"
+	case fatherName == "Пётр" || fatherName == "Петр":
+		stem = "Петр"
+	case fatherName == "Лев":
+		stem = "Льв"
+	case strings.HasSuffix(fatherName, "ья"):
+		stem = strings.TrimSuffix(fatherName, "я")
+		male, female = "ич", "инична"
+	case strings.HasSuffix(fatherName, "а"):
+		stem = strings.TrimSuffix(fatherName, "а")
+		male, female = "ич", "ична"
+	case strings.HasSuffix(fatherName, "й"), strings.HasSuffix(fatherName, "ь"):
+		stem = strings.TrimSuffix(strings.TrimSuffix(fatherName, "й"), "ь")
+		male, female = "евич", "евна"
+	}
+
+	if gender == model.Female {
+		return stem + female
+	}
+	return stem + male
+}
+
+func ukrainianPatronymic(fatherName string, gender model.Gender) string {
+	male, female := "ovych", "ivna"
+	stem := fatherName
+
+	switch {
+	case fatherName == "":
+		return ""
+	case strings.HasSuffix(fatherName, "o"):
+		stem = strings.TrimSuffix(fatherName, "o")
+	case strings.HasSuffix(fatherName, "a"):
+		stem = strings.TrimSuffix(fatherName, "a")
+		male, female = "ayovych", "aivna"
+	case strings.HasSuffix(fatherName, "y") || strings.HasSuffix(fatherName, "i"):
+		stem = fatherName[:len(fatherName)-1]
+		male, female = "yovych", "ivna"
+	}
+
+	if gender == model.Female {
+		return stem + female
+	}
+	return stem + male
+}
+
+func ukrainianCyrillicPatronymic(fatherName string, gender model.Gender) string {
+	male, female := "ович", "івна"
+	maleStem, femaleStem := fatherName, fatherName
+
+	switch {
+	case fatherName == "":
+		return ""
+	case fatherName == "Лев":
+		maleStem, femaleStem = "Льв", "Льв"
+	case strings.HasSuffix(fatherName, "о"):
+		maleStem = strings.TrimSuffix(fatherName, "о")
+		femaleStem = maleStem
+	case strings.HasSuffix(fatherName, "а"):
+		maleStem = strings.TrimSuffix(fatherName, "а")
+		femaleStem = maleStem
+		male, female = "айович", "аївна"
+	case strings.HasSuffix(fatherName, "й"):
+		maleStem = strings.TrimSuffix(fatherName, "й")
+		femaleStem = maleStem
+		male, female = "йович", "ївна"
+	case strings.HasSuffix(fatherName, "ь"):
+		femaleStem = strings.TrimSuffix(fatherName, "ь")
+	}
+
+	if gender == model.Female {
+		return femaleStem + female
+	}
+	return maleStem + male
+}
+
+func icelandicPatronymic(parentName string, parentGender, gender model.Gender) string {
+	suffix := "son"
+	if gender == model.Female {
+		suffix = "dóttir"
+	}
+	return icelandicGenitive(parentName, parentGender) + suffix
+}
+
+func icelandicGenitive(name string, gender model.Gender) string {
+	if gender == model.Female {
+		switch {
+		case strings.HasSuffix(name, "ur"):
+			return strings.TrimSuffix(name, "ur") + "ar"
+		case strings.HasSuffix(name, "a"):
+			return strings.TrimSuffix(name, "a") + "u"
+		default:
+			return name + "ar"
+		}
+	}
+
+	switch {
+	case strings.HasSuffix(name, "ur"):
+		return strings.TrimSuffix(name, "ur") + "s"
+	case strings.HasSuffix(name, "i"):
+		return strings.TrimSuffix(name, "i") + "a"
+	case strings.HasSuffix(name, "s"):
+		return name
+	case strings.HasSuffix(name, "ll"), strings.HasSuffix(name, "nn"):
+		return name[:len(name)-1] + "s"
+	default:
+		return name + "s"
+	}
+}
@@ -11,23 +11,38 @@ import (
 )
 
 type PersonGenerator struct {
-	rng            *rand.SeededRandom
-	repo           *data.Repository
-	prob           *ProbabilityEngine
-	country        string
-	idCounter      uint64
-	countryOptions []string
+	rng                *rand.SeededRandom
+	repo               *data.Repository
+	prob               *ProbabilityEngine
+	probs              map[string]*ProbabilityEngine
+	country            string
+	lifeExpectancyMode LifeExpectancyMode
+	idCounter          uint64
+	countryOptions     []string
+	familyNames        map[string][]string
+	heritage           map[string]string
+	region             string
+	hostNameShare      float64
+	asOf               time.Time
+	projectUntil       time.Time
 }
 
 func NewPersonGenerator(rng *rand.SeededRandom, repo *data.Repository, country string, lifeExpectancyMode LifeExpectancyMode) *PersonGenerator {
 	stats := repo.GetCountryStats(country)
+	prob := NewProbabilityEngine(rng, stats, repo, country, lifeExpectancyMode)
 	return &PersonGenerator{
-		rng:            rng,
-		repo:           repo,
-		prob:           NewProbabilityEngine(rng, stats, repo, country, lifeExpectancyMode),
-		country:        country,
-		idCounter:      0,
-		countryOptions: repo.GetAvailableCountrySlugs(),
+		rng:                rng,
+		repo:               repo,
+		prob:               prob,
+		probs:              map[string]*ProbabilityEngine{country: prob},
+		country:            country,
+		lifeExpectancyMode: lifeExpectancyMode,
+		idCounter:          0,
+		countryOptions:     repo.GetAvailableCountrySlugs(),
+		familyNames:        make(map[string][]string),
+		heritage:           make(map[string]string),
+		hostNameShare:      defaultHostNameShare,
+		asOf:               time.Now(),
 	}
 }
 
@@ -35,89 +50,112 @@ type PersonOptions struct {
 	Gender       model.Gender
 	BirthYear    int
 	Generation   int
-	FatherID     *string
-	MotherID     *string
-	LastName     string
+	Country      string
+	BirthDate    *time.Time
+	Father       *model.Person
+	Mother       *model.Person
+	Surnames     []string
 	WealthIndex  *float64
 	MinAliveDate *time.Time
+	SettledUntil *time.Time
+	Kin          []*model.Person
 }
 
 func (g *PersonGenerator) GeneratePerson(opts PersonOptions) *model.Person {
 	g.idCounter++
 	id := fmt.Sprintf("P%05d", g.idCounter)
 
+	birthDate := g.generateBirthDate(opts.BirthYear)
+	if opts.BirthDate != nil {
+		birthDate = *opts.BirthDate
+	}
+	country := opts.Country
+	if country == "" {
+		country = g.parentalCountry(opts.Father, opts.Mother, birthDate)
+	}
+	prob := g.GetProbabilityEngineFor(country)
+
 	gender := opts.Gender
 	if gender == "" {
-		gender = g.prob.Gender()
+		gender = prob.Gender()
 	}
 
-	firstName := g.generateFirstName(gender, opts.BirthYear)
-	lastName := opts.LastName
-	if lastName == "" {
-		lastName = g.generateLastName()
-	}
+	heritage := heritageCountry(country, opts.Father, opts.Mother)
+	g.heritage[id] = heritage
+	firstName := g.generateFirstName(g.pickNameCountry(country, heritage, gender), gender, opts.BirthYear)
 
-	birthDate := g.generateBirthDate(opts.BirthYear)
+	person := model.NewPerson(id, firstName, "", gender, birthDate, country, opts.Generation)
+	g.assignGivenNames(person, sameGender(gender, opts.Father, opts.Mother))
 
-	person := model.NewPerson(id, firstName, lastName, gender, birthDate, g.country, opts.Generation)
+	if opts.Father != nil {
+		person.FatherID = &opts.Father.ID
+		person.AddParent(opts.Father.ID, model.BiologicalParent, nil)
+	}
+	if opts.Mother != nil {
+		person.MotherID = &opts.Mother.ID
+		person.AddParent(opts.Mother.ID, model.BiologicalParent, nil)
+	}
 
-	person.FatherID = opts.FatherID
-	person.MotherID = opts.MotherID
+	surnames := opts.Surnames
+	if len(surnames) == 0 {
+		surnames = g.inheritedSurnames(namingCustomFor(person.BirthCountry), opts.Father, opts.Mother)
+	}
+	g.setBirthNames(person, surnames, opts.Father, opts.Mother)
 
-	person.BornOutsideMarriage = g.prob.ShouldBeBornOutsideMarriage(opts.BirthYear)
-	person.Underweight = g.prob.ShouldBeUnderweight()
-	person.Residence = g.determineResidenceForCountry(g.country, opts.BirthYear)
-	person.GDPPerCapita = g.repo.GetGDPPerCapita(g.country)
+	person.Underweight = prob.ShouldBeUnderweight()
+	person.Height = prob.SampleHeight(gender, opts.BirthYear, opts.Kin)
+	person.Residence = g.determineResidenceForCountry(country, opts.BirthYear)
 	person.WealthIndex = g.getWealthIndex(opts.WealthIndex)
-	g.assignWealth(person)
-
-	person.Health = g.prob.GenerateHealthProfile()
-
-	deathAge := g.prob.CalculateDeathAge(person.Health, opts.BirthYear, gender)
+	g.assignWealth(person, opts.BirthYear)
 
-	if g.prob.ShouldDieInInfancy() {
-		deathAge = 0
-		infantDeathDate := birthDate.AddDate(0, g.rng.IntRange(0, 11), g.rng.IntRange(0, 28))
-		person.DeathDate = &infantDeathDate
-	} else if g.prob.ShouldDieInYouth(opts.BirthYear) {
+	person.Health = prob.GenerateHealthProfile()
 
-		deathAge = g.rng.IntRange(1, 14)
-		youthDeathDate := birthDate.AddDate(deathAge, g.rng.IntRange(0, 11), g.rng.IntRange(1, 28))
-		person.DeathDate = &youthDeathDate
-	} else if deathAge <= person.Age(time.Now()) {
-
-		deathDate := birthDate.AddDate(deathAge, g.rng.IntRange(0, 11), g.rng.IntRange(1, 28))
+	deathAge := prob.SampleDeathAge(person.Health, opts.BirthYear, gender)
+	deathDate := birthDate.AddDate(0, 0, int(deathAge*daysPerYear))
+	if deathDate.Before(g.observedUntil()) {
 		person.DeathDate = &deathDate
 	}
 
 	g.applySafetyConstraints(person, opts.BirthYear, opts.MinAliveDate)
 
-	currentAge := person.Age(time.Now())
+	currentAge := person.Age(g.asOf)
 	if person.DeathDate != nil {
 		currentAge = person.AgeAtDeath()
 	}
 
-	person.Education = g.prob.DetermineEducation()
-	person.Employment = g.prob.DetermineEmployment(currentAge)
+	person.Education = prob.DetermineEducation(opts.BirthYear, opts.Kin)
+	person.Employment = prob.DetermineEmployment(currentAge)
 
 	person.MaritalStatus = model.Single
 
-	birthEvent := model.NewLifeEvent(model.EventBirth, birthDate, g.country)
+	birthEvent := model.NewLifeEvent(model.EventBirth, birthDate, country)
 	person.Events = append(person.Events, birthEvent)
 
-	g.maybeMigrate(person)
+	g.maybeMigrate(person, opts.SettledUntil)
 
-	if person.DeathDate != nil {
-		deathEvent := model.NewLifeEvent(model.EventDeath, *person.DeathDate, person.CurrentCountry)
-		person.Events = append(person.Events, deathEvent)
-	}
+	ensureDeathEvent(person)
 
 	return person
 }
 
-func (g *PersonGenerator) generateFirstName(gender model.Gender, birthYear int) string {
+func (g *PersonGenerator) generateFirstName(country string, gender model.Gender, birthYear int) string {
 	genderStr := string(gender)
-	names := g.repo.GetForenamesByGender(g.country, genderStr)
+	if weight := g.repo.EarlyModernWeight(birthYear); weight > 0 && g.rng.Chance(weight) {
+		if names := g.repo.GetParishForenames(country, genderStr); len(names) > 0 {
+			weights := make([]float64, len(names))
+			for i := range names {
+				weights[i] = 1.0 / float64(i+1)
+			}
+			return names[g.rng.WeightedChoice(weights)]
+		}
+	}
+	var names []data.NameRecord
+	if country == g.country {
+		names = g.repo.GetForenamesByRegion(country, genderStr, g.region)
+	}
+	if len(names) == 0 {
+		names = g.repo.GetForenamesByGender(country, genderStr)
+	}
 
 	if len(names) == 0 {
 
@@ -147,8 +185,11 @@ func (g *PersonGenerator) generateFirstName(gender model.Gender, birthYear int)
 	return name
 }
 
-func (g *PersonGenerator) generateLastName() string {
-	surnames := g.repo.GetSurnames(g.country)
+func (g *PersonGenerator) generateLastName(country string) string {
+	surnames := g.repo.GetSurnames(country)
+	if namingCustomFor(country).usesGenderedSurnames() {
+		surnames = masculineSurnames(surnames)
+	}
 
 	if len(surnames) == 0 {
 		return rand.Choice(g.rng, []string{"Smith", "Johnson", "Williams", "Brown", "Jones"})
@@ -219,6 +260,19 @@ func (g *PersonGenerator) generateLastName() string {
 	return pickSurnameName(surnames[idx])
 }
 
+func masculineSurnames(surnames []data.SurnameRecord) []data.SurnameRecord {
+	seen := make(map[int]bool, len(surnames))
+	filtered := make([]data.SurnameRecord, 0, len(surnames))
+	for _, s := range surnames {
+		if s.Rank > 0 && seen[s.Rank] {
+			continue
+		}
+		seen[s.Rank] = true
+		filtered = append(filtered, s)
+	}
+	return filtered
+}
+
 func pickSurnameName(s data.SurnameRecord) string {
 	if s.RomanizedName != "" {
 		return s.RomanizedName
@@ -237,6 +291,19 @@ func (g *PersonGenerator) generateBirthDate(year int) time.Time {
 	return time.Date(year, time.Month(month), day, 0, 0, 0, 0, time.UTC)
 }
 
+func (g *PersonGenerator) spacedBirthDate(date time.Time, taken []time.Time) time.Time {
+	for moved := true; moved; {
+		moved = false
+		for _, other := range taken {
+			if date.After(other.AddDate(0, -minBirthIntervalMonths, 0)) && date.Before(other.AddDate(0, minBirthIntervalMonths, 0)) {
+				date = other.AddDate(0, minBirthIntervalMonths+g.rng.IntRange(0, 5), g.rng.IntRange(0, 27))
+				moved = true
+			}
+		}
+	}
+	return date
+}
+
 func (g *PersonGenerator) randomDateAtAge(birthDate time.Time, ageYears int) time.Time {
 	return birthDate.AddDate(ageYears, g.rng.IntRange(0, 11), g.rng.IntRange(1, 28))
 }
@@ -255,7 +322,7 @@ func (g *PersonGenerator) applySafetyConstraints(person *model.Person, birthYear
 		}
 	}
 
-	maxAge := g.prob.MaxAllowedAge(birthYear, person.Gender)
+	maxAge := g.GetProbabilityEngineFor(person.BirthCountry).MaxAllowedAge(birthYear, person.Gender)
 
 	if person.DeathDate != nil {
 		if person.DeathDate.Before(person.BirthDate) {
@@ -268,7 +335,7 @@ func (g *PersonGenerator) applySafetyConstraints(person *model.Person, birthYear
 			person.DeathDate = &adjusted
 			ensureDeathEvent(person)
 		}
-	} else if person.Age(time.Now()) > maxAge {
+	} else if person.Age(g.observedUntil()) > maxAge {
 		adjusted := g.randomDateAtAge(person.BirthDate, maxAge)
 		person.DeathDate = &adjusted
 		ensureDeathEvent(person)
@@ -323,8 +390,8 @@ func (g *PersonGenerator) blendWealthIndex(parent float64, weight float64) float
 	return value
 }
 
-func (g *PersonGenerator) assignWealth(person *model.Person) {
-	person.GDPPerCapita = g.repo.GetGDPPerCapita(person.CurrentCountry)
+func (g *PersonGenerator) assignWealth(person *model.Person, year int) {
+	person.GDPPerCapita = g.repo.GetGDPPerCapitaAt(person.CurrentCountry, year)
 	person.FamilyWealth = person.GDPPerCapita * person.WealthIndex
 	person.IsRich = person.WealthIndex >= 1.5
 }
@@ -337,8 +404,8 @@ func (g *PersonGenerator) determineResidenceForCountry(country string, year int)
 	return model.Rural
 }
 
-func (g *PersonGenerator) maybeMigrate(person *model.Person) {
-	if !g.prob.ShouldMigrate() || len(g.countryOptions) < 2 {
+func (g *PersonGenerator) maybeMigrate(person *model.Person, settledUntil *time.Time) {
+	if len(g.countryOptions) < 2 {
 		return
 	}
 
@@ -347,79 +414,143 @@ func (g *PersonGenerator) maybeMigrate(person *model.Person) {
 	if person.DeathDate != nil && !person.DeathDate.After(migrationDate) {
 		return
 	}
-
-	var destination string
-	for i := 0; i < 10; i++ {
-		candidate := rand.Choice(g.rng, g.countryOptions)
-		if candidate != "" && candidate != person.BirthCountry {
-			destination = candidate
-			break
-		}
+	if settledUntil != nil && migrationDate.Before(*settledUntil) {
+		return
 	}
-	if destination == "" {
-		for _, candidate := range g.countryOptions {
-			if candidate != person.BirthCountry {
-				destination = candidate
-				break
-			}
-		}
+
+	origin := person.CurrentCountry
+	if !g.GetProbabilityEngineFor(origin).ShouldMigrate(origin, person.BirthDate.Year()) {
+		return
 	}
+
+	destination := g.chooseMigrationDestination(origin, migrationDate.Year())
 	if destination == "" {
 		return
 	}
 
-	origin := person.CurrentCountry
 	person.CurrentCountry = destination
 	person.Events = append(person.Events, model.NewLifeEvent(model.EventMigration, migrationDate, destination).WithDescription(origin))
 	person.Residence = g.determineResidenceForCountry(destination, migrationDate.Year())
-	g.assignWealth(person)
+	g.assignWealth(person, migrationDate.Year())
 }
 
-func (g *PersonGenerator) GenerateSpouse(person *model.Person) *model.Person {
+func (g *PersonGenerator) GenerateSpouse(person *model.Person, sameSex bool) *model.Person {
 
-	var spouseGender model.Gender
-	if person.Gender == model.Male {
-		spouseGender = model.Female
-	} else {
-		spouseGender = model.Male
-	}
+	spouseGender := partnerGender(person, sameSex)
 
 	ageDiff := g.rng.IntRange(-5, 5)
 	spouseBirthYear := person.BirthDate.Year() + ageDiff
+	adulthood := time.Date(spouseBirthYear+18, time.December, 31, 0, 0, 0, 0, time.UTC)
 
 	spouseWealth := g.blendWealthIndex(person.WealthIndex, 0.7)
 	spouse := g.GeneratePerson(PersonOptions{
-		Gender:      spouseGender,
-		BirthYear:   spouseBirthYear,
-		Generation:  person.Generation,
-		WealthIndex: &spouseWealth,
+		Gender:       spouseGender,
+		BirthYear:    spouseBirthYear,
+		Generation:   person.Generation,
+		Country:      person.CountryAt(adulthood),
+		WealthIndex:  &spouseWealth,
+		MinAliveDate: &adulthood,
 	})
 
 	return spouse
 }
 
-func (g *PersonGenerator) GenerateChild(father, mother *model.Person, childIndex int) *model.Person {
+func (g *PersonGenerator) GenerateRemarriageSpouse(person *model.Person, marriageDate time.Time, sameSex bool) *model.Person {
 
-	birthYear := g.prob.CalculateChildBirthYear(mother.BirthDate.Year(), childIndex)
+	spouseGender := partnerGender(person, sameSex)
 
-	lastName := father.LastName
-	parentWealth := (father.WealthIndex + mother.WealthIndex) / 2
-	childWealth := g.blendWealthIndex(parentWealth, 0.7)
+	ageDiff := g.rng.IntRange(-10, 6)
+	spouseBirthYear := person.BirthDate.Year() + ageDiff
+	if latest := marriageDate.Year() - 18; spouseBirthYear > latest {
+		spouseBirthYear = latest
+	}
+
+	spouseWealth := g.blendWealthIndex(person.WealthIndex, 0.6)
+	spouse := g.GeneratePerson(PersonOptions{
+		Gender:       spouseGender,
+		BirthYear:    spouseBirthYear,
+		Generation:   person.Generation,
+		Country:      person.CountryAt(marriageDate),
+		WealthIndex:  &spouseWealth,
+		MinAliveDate: &marriageDate,
+	})
+
+	return spouse
+}
+
+func partnerGender(person *model.Person, sameSex bool) model.Gender {
+	if sameSex {
+		return person.Gender
+	}
+	if person.Gender == model.Male {
+		return model.Female
+	}
+	return model.Male
+}
+
+func (g *PersonGenerator) GenerateAdoptedChild(partner, other *model.Person, adoptionDate time.Time) *model.Person {
+	birthYear := adoptionDate.Year() - g.rng.IntRange(1, 6)
+
+	parentWealth := (partner.WealthIndex + other.WealthIndex) / 2
+	childWealth := g.blendWealthIndex(parentWealth, 0.5)
 
 	child := g.GeneratePerson(PersonOptions{
-		BirthYear:   birthYear,
-		Generation:  father.Generation + 1,
-		FatherID:    &father.ID,
-		MotherID:    &mother.ID,
-		LastName:    lastName,
-		WealthIndex: &childWealth,
+		BirthYear:    birthYear,
+		Generation:   partner.Generation + 1,
+		Country:      partner.CountryAt(adoptionDate),
+		WealthIndex:  &childWealth,
+		MinAliveDate: &adoptionDate,
 	})
+	child.Adopted = true
+	father, mother := adoptiveParents(partner, other)
+	g.inheritNames(child, father, mother)
 
 	return child
 }
 
+func (g *PersonGenerator) GenerateChild(father, mother *model.Person, birthDate time.Time) *model.Person {
+	opts := g.childOptions(father, mother, birthDate.Year())
+	opts.BirthDate = &birthDate
+	return g.GeneratePerson(opts)
+}
+
+func (g *PersonGenerator) GenerateTwin(twin, father, mother *model.Person, identical bool) *model.Person {
+	opts := g.childOptions(father, mother, twin.BirthDate.Year())
+	opts.BirthDate = &twin.BirthDate
+	if identical {
+		opts.Gender = twin.Gender
+	}
+	return g.GeneratePerson(opts)
+}
+
+func (g *PersonGenerator) childOptions(father, mother *model.Person, birthYear int) PersonOptions {
+	opts := PersonOptions{BirthYear: birthYear}
+
+	var parentWealth float64
+	if mother != nil {
+		opts.Generation = mother.Generation + 1
+		opts.Mother = mother
+		parentWealth = mother.WealthIndex
+	}
+	if father != nil {
+		if mother == nil {
+			parentWealth = father.WealthIndex
+		} else {
+			parentWealth = (father.WealthIndex + mother.WealthIndex) / 2
+		}
+		opts.Generation = father.Generation + 1
+		opts.Father = father
+	}
+
+	childWealth := g.blendWealthIndex(parentWealth, 0.7)
+	opts.WealthIndex = &childWealth
+	opts.Kin = []*model.Person{father, mother}
+
+	return opts
+}
+
 func (g *PersonGenerator) GenerateParent(child *model.Person, gender model.Gender) *model.Person {
-	birthYear := g.prob.CalculateParentBirthYear(child.BirthDate.Year(), gender)
+	birthYear := g.GetProbabilityEngineFor(child.BirthCountry).CalculateParentBirthYear(child.BirthDate.Year(), gender)
 	parentWealth := g.blendWealthIndex(child.WealthIndex, 0.6)
 	minAliveDate := child.BirthDate
 
@@ -427,36 +558,31 @@ func (g *PersonGenerator) GenerateParent(child *model.Person, gender model.Gende
 		Gender:       gender,
 		BirthYear:    birthYear,
 		Generation:   child.Generation - 1,
+		Country:      child.BirthCountry,
 		WealthIndex:  &parentWealth,
 		MinAliveDate: &minAliveDate,
+		SettledUntil: &minAliveDate,
+		Kin:          []*model.Person{child},
 	}
 
-	if gender == model.Male && child.LastName != "" {
-		opts.LastName = child.LastName
+	if gender == model.Male {
+		opts.Surnames = g.familySurnames(child)
 	}
 
 	return g.GeneratePerson(opts)
 }
 
-func (g *PersonGenerator) GenerateSibling(person *model.Person, father, mother *model.Person, siblingIndex int) *model.Person {
-
-	ageDiff := g.rng.IntRange(-8, 8)
-	birthYear := person.BirthDate.Year() + ageDiff
-
-	minBirthYear := mother.BirthDate.Year() + 18
-	if birthYear < minBirthYear {
-		birthYear = minBirthYear + siblingIndex*2
-	}
-
+func (g *PersonGenerator) GenerateSibling(person *model.Person, father, mother *model.Person, birthDate time.Time) *model.Person {
 	parentWealth := (father.WealthIndex + mother.WealthIndex) / 2
 	siblingWealth := g.blendWealthIndex(parentWealth, 0.8)
 	sibling := g.GeneratePerson(PersonOptions{
-		BirthYear:   birthYear,
+		BirthYear:   birthDate.Year(),
+		BirthDate:   &birthDate,
 		Generation:  person.Generation,
-		FatherID:    &father.ID,
-		MotherID:    &mother.ID,
-		LastName:    father.LastName,
+		Father:      father,
+		Mother:      mother,
 		WealthIndex: &siblingWealth,
+		Kin:         []*model.Person{father, mother},
 	})
 
 	return sibling
@@ -466,6 +592,29 @@ func (g *PersonGenerator) GetProbabilityEngine() *ProbabilityEngine {
 	return g.prob
 }
 
+func (g *PersonGenerator) GetProbabilityEngineFor(country string) *ProbabilityEngine {
+	if prob, ok := g.probs[country]; ok {
+		return prob
+	}
+
+	prob := g.prob
+	if stats := g.repo.GetCountryStats(country); stats.LifeExpectancy > 0 {
+		prob = NewProbabilityEngine(g.rng, stats, g.repo, country, g.lifeExpectancyMode)
+	}
+	g.probs[country] = prob
+	return prob
+}
+
+func (g *PersonGenerator) parentalCountry(father, mother *model.Person, date time.Time) string {
+	if mother != nil {
+		return mother.CountryAt(date)
+	}
+	if father != nil {
+		return father.CountryAt(date)
+	}
+	return g.country
+}
+
 func (g *PersonGenerator) GetCurrentID() uint64 {
 	return g.idCounter
 }
@@ -2,6 +2,7 @@ package generator
 
 import (
 	"math"
+	"sort"
 
 	"github.com/familytree-generator/internal/data"
 	"github.com/familytree-generator/internal/model"
@@ -14,6 +15,8 @@ type ProbabilityEngine struct {
 	repo               *data.Repository
 	country            string
 	lifeExpectancyMode LifeExpectancyMode
+	lifeTables         map[lifeTableKey]*lifeTable
+	gompertzBaselines  map[model.Gender]float64
 }
 
 func NewProbabilityEngine(rng *rand.SeededRandom, stats *data.CountryStats, repo *data.Repository, country string, mode LifeExpectancyMode) *ProbabilityEngine {
@@ -23,18 +26,21 @@ func NewProbabilityEngine(rng *rand.SeededRandom, stats *data.CountryStats, repo
 		repo:               repo,
 		country:            country,
 		lifeExpectancyMode: mode,
+		lifeTables:         make(map[lifeTableKey]*lifeTable),
+		gompertzBaselines:  make(map[model.Gender]float64),
 	}
 }
 
 func (p *ProbabilityEngine) CalculateChildrenCount(year int) int {
 
 	tfr := p.repo.GetFertilityRate(p.country, year)
+	parity := tfr / (1 - p.childlessShare(year))
 
-	children := p.rng.NormalDistribution(tfr, tfr*0.25)
+	children := p.rng.NormalDistribution(parity, parity*0.25)
 
 	result := int(math.Round(children))
-	if result < 0 {
-		result = 0
+	if result < 1 {
+		result = 1
 	}
 	if result > 12 {
 		result = 12
@@ -43,6 +49,23 @@ func (p *ProbabilityEngine) CalculateChildrenCount(year int) int {
 	return result
 }
 
+func (p *ProbabilityEngine) ShouldRemainChildless(year int) bool {
+	return p.rng.Chance(p.childlessShare(year))
+}
+
+func (p *ProbabilityEngine) childlessShare(year int) float64 {
+	tfr := p.repo.GetFertilityRate(p.country, year)
+
+	share := 0.30 - 0.05*tfr
+	if share < 0.03 {
+		share = 0.03
+	}
+	if share > 0.25 {
+		share = 0.25
+	}
+	return share
+}
+
 func (p *ProbabilityEngine) CalculateChildrenCountLegacy() int {
 	birthRate := p.stats.BirthRate
 	avgChildren := birthRate / 8.0
@@ -66,56 +89,6 @@ func (p *ProbabilityEngine) CalculateChildrenCountLegacy() int {
 	return result
 }
 
-func (p *ProbabilityEngine) CalculateDeathAge(health model.HealthProfile, birthYear int, gender model.Gender) int {
-	baseAge := p.adjustedLifeExpectancy(birthYear, p.baseLifeExpectancy(gender))
-
-	if health.TobaccoUse {
-		baseAge -= p.rng.Float64Range(5, 10)
-	}
-
-	if health.AlcoholConsumption > 10 {
-		baseAge -= p.rng.Float64Range(2, 5)
-	}
-
-	deathAge := p.rng.NormalDistribution(baseAge, 8)
-
-	if deathAge < 1 {
-		deathAge = 1
-	}
-	maxAge := float64(p.MaxAllowedAge(birthYear, gender))
-	if deathAge > maxAge {
-		deathAge = maxAge
-	}
-
-	return int(math.Round(deathAge))
-}
-
-func (p *ProbabilityEngine) adjustedLifeExpectancy(birthYear int, baseAge float64) float64 {
-
-	if birthYear < 1950 {
-		baseAge = baseAge * 0.75
-	} else if birthYear < 1980 {
-		baseAge = baseAge * 0.9
-	}
-
-	if baseAge < 40 {
-		baseAge = 40
-	}
-
-	return baseAge
-}
-
-func (p *ProbabilityEngine) MaxAllowedAge(birthYear int, gender model.Gender) int {
-	maxAge := p.adjustedLifeExpectancy(birthYear, p.baseLifeExpectancy(gender)) + 40
-	if maxAge < 50 {
-		maxAge = 50
-	}
-	if maxAge > maxHumanAgeYears {
-		maxAge = maxHumanAgeYears
-	}
-	return int(math.Round(maxAge))
-}
-
 func (p *ProbabilityEngine) baseLifeExpectancy(gender model.Gender) float64 {
 	base := p.stats.LifeExpectancy
 	switch p.lifeExpectancyMode {
@@ -140,19 +113,6 @@ func (p *ProbabilityEngine) baseLifeExpectancy(gender model.Gender) float64 {
 	return base
 }
 
-func (p *ProbabilityEngine) ShouldDieInInfancy() bool {
-	imr := p.stats.InfantMortality
-	probability := imr / 1000.0
-	return p.rng.Chance(probability)
-}
-
-func (p *ProbabilityEngine) ShouldDieInYouth(birthYear int) bool {
-	youthMortality := p.repo.GetYouthMortality(p.country, birthYear)
-
-	probability := youthMortality / 100.0
-	return p.rng.Chance(probability)
-}
-
 func (p *ProbabilityEngine) CalculateMarriageAge(gender model.Gender, birthYear int) int {
 	var baseAge float64
 
@@ -177,17 +137,77 @@ func (p *ProbabilityEngine) CalculateMarriageAge(gender model.Gender, birthYear
 	return int(math.Round(age))
 }
 
-func (p *ProbabilityEngine) ShouldGetDivorced(marriageYear int) bool {
-	divorceRate := p.repo.GetDivorceRate(p.country, marriageYear)
+func (p *ProbabilityEngine) DetermineUnionType(year int) model.UnionType {
+	outside := p.repo.GetBirthsOutsideMarriage(p.country, year) / 100.0
+	singleParent := p.repo.GetSingleParentShare(p.country, year) / 100.0
+	if singleParent > outside {
+		singleParent = outside
+	}
+
+	roll := p.rng.Float64()
+	if roll < singleParent {
+		return model.UnionNone
+	}
+	if roll < outside {
+		return model.UnionCohabitation
+	}
+	return model.UnionMarriage
+}
 
-	probability := divorceRate / 1000.0 * 10
-	return p.rng.Chance(probability)
+func (p *ProbabilityEngine) DetermineSameSexUnion(year int) (model.UnionType, bool) {
+	switch p.repo.GetSameSexUnionStatus(p.country, year) {
+	case data.SameSexMarriage:
+		if p.rng.Chance(sameSexUnionShare) {
+			return model.UnionMarriage, true
+		}
+	case data.SameSexCivilUnion:
+		if p.rng.Chance(sameSexUnionShare * 0.6) {
+			return model.UnionCivilPartnership, true
+		}
+	}
+	return "", false
 }
 
-func (p *ProbabilityEngine) ShouldBeBornOutsideMarriage(birthYear int) bool {
-	share := p.repo.GetBirthsOutsideMarriage(p.country, birthYear)
+func (p *ProbabilityEngine) CalculateAdoptionCount(year int) int {
+	tfr := p.repo.GetFertilityRate(p.country, year)
+	if !p.rng.Chance(0.3) {
+		return 0
+	}
+	if tfr > 2.5 {
+		return p.rng.IntRange(1, 3)
+	}
+	return p.rng.IntRange(1, 2)
+}
 
-	return p.rng.Chance(share / 100.0)
+func (p *ProbabilityEngine) SampleHeight(gender model.Gender, birthYear int, kin []*model.Person) float64 {
+	var kinZ float64
+	var known int
+	for _, relative := range kin {
+		if relative == nil || relative.Height <= 0 {
+			continue
+		}
+		kinZ += p.HeightZScore(relative)
+		known++
+	}
+
+	regression := heightHeritability / 2
+	residualSD := math.Sqrt(1 - regression*regression*float64(known))
+	z := regression*kinZ + p.rng.NormalDistribution(0, residualSD)
+
+	mean := p.repo.GetAverageHeight(p.country, string(gender), birthYear)
+	return math.Round((mean+z*heightSD(gender))*10) / 10
+}
+
+func (p *ProbabilityEngine) HeightZScore(person *model.Person) float64 {
+	mean := p.repo.GetAverageHeight(person.BirthCountry, string(person.Gender), person.BirthDate.Year())
+	return (person.Height - mean) / heightSD(person.Gender)
+}
+
+func heightSD(gender model.Gender) float64 {
+	if gender == model.Female {
+		return heightSDWomen
+	}
+	return heightSDMen
 }
 
 func (p *ProbabilityEngine) ShouldBeUnderweight() bool {
@@ -211,18 +231,21 @@ func (p *ProbabilityEngine) ShouldBeSingleParent(year int) bool {
 func (p *ProbabilityEngine) ShouldGetMarried(birthYear int) bool {
 	marriageRate := p.repo.GetMarriageRate(p.country, birthYear+28)
 
-	baseProbability := 0.85
-	if marriageRate < 5 {
+	baseProbability := 0.70 + (marriageRate-4)*0.05
+	if baseProbability < 0.70 {
 		baseProbability = 0.70
-	} else if marriageRate > 8 {
+	}
+	if baseProbability > 0.95 {
 		baseProbability = 0.95
 	}
 	return p.rng.Chance(baseProbability)
 }
 
-func (p *ProbabilityEngine) ShouldMigrate() bool {
-	migRate := p.stats.MigrationRate
-	probability := math.Abs(migRate) / 1000.0 * 0.5
+func (p *ProbabilityEngine) ShouldMigrate(origin string, birthYear int) bool {
+	probability := p.emigrationChance(origin, birthYear)
+	if probability <= 0 {
+		probability = math.Abs(p.stats.MigrationRate) / 1000.0 * 0.5
+	}
 	return p.rng.Chance(probability)
 }
 
@@ -260,48 +283,124 @@ func (p *ProbabilityEngine) DetermineEmployment(age int) model.EmploymentStatus
 	return model.Employed
 }
 
-func (p *ProbabilityEngine) DetermineEducation() model.EducationLevel {
-	eduExp := p.stats.EducationExpenditure
-	gdp := p.stats.GDPPerCapita
+var educationLevels = []model.EducationLevel{
+	model.NoEducation,
+	model.Primary,
+	model.Secondary,
+	model.Tertiary,
+}
+
+func (p *ProbabilityEngine) DetermineEducation(birthYear int, kin []*model.Person) model.EducationLevel {
+	var kinZ float64
+	var known int
+	for _, relative := range kin {
+		if relative == nil || relative.Education == "" {
+			continue
+		}
+		kinZ += p.educationZScore(relative)
+		known++
+	}
+
+	z := p.rng.NormFloat64()
+	if known > 0 {
+		kinZ /= float64(known)
+		z = educationTransmission*kinZ + math.Sqrt(1-educationTransmission*educationTransmission)*z
+	}
+
+	thresholds := p.educationThresholds(p.country, birthYear)
+	for i, threshold := range thresholds {
+		if z < threshold {
+			return educationLevels[i]
+		}
+	}
+	return model.Tertiary
+}
+
+func (p *ProbabilityEngine) educationShares(country string, birthYear int) []float64 {
+	schoolYear := birthYear + educationCohortAge
+	literacy := p.repo.GetLiteracyRate(country, schoolYear) / 100
+	if literacy < 0.01 {
+		literacy = 0.01
+	}
+	if literacy > 0.995 {
+		literacy = 0.995
+	}
 
-	developmentScore := (gdp / 50000) + (eduExp / 10)
+	developmentScore := (p.stats.GDPPerCapita / 50000) + (p.stats.EducationExpenditure / 10)
 	if developmentScore > 1 {
 		developmentScore = 1
 	}
 
-	roll := p.rng.Float64()
+	secondaryExpansion := logistic(float64(schoolYear-1955) / 15)
+	tertiaryExpansion := logistic(float64(schoolYear-1980) / 12)
 
-	if developmentScore > 0.7 {
-		if roll < 0.05 {
-			return model.NoEducation
-		} else if roll < 0.20 {
-			return model.Primary
-		} else if roll < 0.55 {
-			return model.Secondary
-		} else {
-			return model.Tertiary
+	tertiary := literacy * literacy * tertiaryExpansion * (0.10 + 0.30*developmentScore)
+	if tertiary < 0.005*literacy {
+		tertiary = 0.005 * literacy
+	}
+	secondary := (literacy - tertiary) * literacy * (0.1 + 0.8*secondaryExpansion)
+	primary := literacy - tertiary - secondary
+
+	return []float64{1 - literacy, primary, secondary, tertiary}
+}
+
+func (p *ProbabilityEngine) educationThresholds(country string, birthYear int) []float64 {
+	shares := p.educationShares(country, birthYear)
+	thresholds := make([]float64, 0, len(shares)-1)
+	var cumulative float64
+	for _, share := range shares[:len(shares)-1] {
+		cumulative += share
+		thresholds = append(thresholds, normalQuantile(cumulative))
+	}
+	return thresholds
+}
+
+func (p *ProbabilityEngine) educationZScore(person *model.Person) float64 {
+	thresholds := p.educationThresholds(person.BirthCountry, person.BirthDate.Year())
+	lower, upper := math.Inf(-1), math.Inf(1)
+	for i, level := range educationLevels {
+		if level != person.Education {
+			continue
 		}
-	} else if developmentScore > 0.4 {
-		if roll < 0.10 {
-			return model.NoEducation
-		} else if roll < 0.35 {
-			return model.Primary
-		} else if roll < 0.75 {
-			return model.Secondary
-		} else {
-			return model.Tertiary
+		if i > 0 {
+			lower = thresholds[i-1]
 		}
-	} else {
-		if roll < 0.20 {
-			return model.NoEducation
-		} else if roll < 0.55 {
-			return model.Primary
-		} else if roll < 0.85 {
-			return model.Secondary
-		} else {
-			return model.Tertiary
+		if i < len(thresholds) {
+			upper = thresholds[i]
 		}
+		break
+	}
+
+	mass := normalCDF(upper) - normalCDF(lower)
+	if mass <= 0 {
+		return 0
+	}
+	return (normalPDF(lower) - normalPDF(upper)) / mass
+}
+
+func logistic(x float64) float64 {
+	return 1 / (1 + math.Exp(-x))
+}
+
+func normalCDF(x float64) float64 {
+	return 0.5 * math.Erfc(-x/math.Sqrt2)
+}
+
+func normalPDF(x float64) float64 {
+	if math.IsInf(x, 0) {
+		return 0
+	}
+	return math.Exp(-x*x/2) / math.Sqrt(2*math.Pi)
+}
+
+func normalQuantile(p float64) float64 {
+	if p <= 0 {
+		return math.Inf(-1)
+	}
+	if p >= 1 {
+		return math.Inf(1)
 	}
+	return math.Sqrt2 * math.Erfinv(2*p-1)
 }
 
 func (p *ProbabilityEngine) GenerateHealthProfile() model.HealthProfile {
@@ -324,10 +423,59 @@ func (p *ProbabilityEngine) CalculateChildBirthYear(motherBirthYear, childIndex
 	return motherBirthYear + motherAgeAtFirstChild + spacing
 }
 
+func (p *ProbabilityEngine) SampleMotherAgesAtBirth(motherBirthYear, count, minAge int) []int {
+	ages, rates := p.repo.GetCohortFertilitySchedule(p.country, motherBirthYear)
+
+	result := make([]int, 0, count)
+	if len(ages) == 0 {
+		for i := 0; i < count; i++ {
+			age := p.CalculateChildBirthYear(motherBirthYear, i) - motherBirthYear
+			result = append(result, max(age, minAge))
+		}
+	} else {
+		ages, rates = truncateSchedule(ages, rates, minAge)
+		if len(ages) == 0 {
+			return nil
+		}
+		for i := 0; i < count; i++ {
+			result = append(result, ages[p.rng.WeightedChoice(rates)])
+		}
+	}
+
+	sort.Ints(result)
+	return result
+}
+
+func truncateSchedule(ages []int, rates []float64, minAge int) ([]int, []float64) {
+	if minAge < minMotherAgeAtBirth {
+		minAge = minMotherAgeAtBirth
+	}
+
+	keptAges := make([]int, 0, len(ages))
+	keptRates := make([]float64, 0, len(rates))
+	for i, age := range ages {
+		if age < minAge || age > maxMotherAgeAtBirth || rates[i] <= 0 {
+			continue
+		}
+		keptAges = append(keptAges, age)
+		keptRates = append(keptRates, rates[i])
+	}
+	return keptAges, keptRates
+}
+
 func (p *ProbabilityEngine) CalculateParentBirthYear(childBirthYear int, parentGender model.Gender) int {
 	var ageGap int
-	if parentGender == model.Female {
-		ageGap = p.rng.IntRange(22, 32)
+	if parentGender == model.Female || p.repo.InEarlyModernProfile(childBirthYear) {
+		ages, rates := p.repo.GetPeriodFertilitySchedule(p.country, childBirthYear)
+		ages, rates = truncateSchedule(ages, rates, 0)
+		if len(ages) > 0 {
+			ageGap = ages[p.rng.WeightedChoice(rates)]
+		} else {
+			ageGap = p.rng.IntRange(22, 32)
+		}
+		if parentGender == model.Male {
+			ageGap += p.rng.IntRange(2, 4)
+		}
 	} else {
 		ageGap = p.rng.IntRange(25, 38)
 	}
@@ -343,8 +491,14 @@ func (p *ProbabilityEngine) CalculateSiblingCount(year int) int {
 	return count
 }
 
-func (p *ProbabilityEngine) ShouldRemarry() bool {
-	return p.rng.Chance(0.40)
+func (p *ProbabilityEngine) ShouldRemarry(age int) bool {
+	probability := 0.40
+	if age >= 55 {
+		probability = 0.10
+	} else if age >= 40 {
+		probability = 0.25
+	}
+	return p.rng.Chance(probability)
 }
 
 func (p *ProbabilityEngine) Gender() model.Gender {
@@ -354,12 +508,6 @@ func (p *ProbabilityEngine) Gender() model.Gender {
 	return model.Female
 }
 
-func (p *ProbabilityEngine) CalculateDivorceYear(marriageYear int) int {
-
-	yearsMarried := p.rng.IntRange(2, 15)
-	return marriageYear + yearsMarried
-}
-
 func (p *ProbabilityEngine) DetermineMaritalStatus(person *model.Person, hasSpouse bool, isDivorced bool) model.MaritalStatus {
 	if !hasSpouse {
 		return model.Single
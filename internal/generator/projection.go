@@ -0,0 +1,31 @@
+package generator
+
+import "time"
+
+func (g *PersonGenerator) useProjection(asOf time.Time, projectUntil int) {
+	g.asOf = asOf
+	g.projectUntil = time.Time{}
+	if projectUntil > asOf.Year() {
+		g.projectUntil = time.Date(projectUntil, time.December, 31, 0, 0, 0, 0, time.UTC)
+	}
+}
+
+func (g *PersonGenerator) observedUntil() time.Time {
+	if g.projectUntil.IsZero() {
+		return g.asOf
+	}
+	return g.projectUntil
+}
+
+func (g *PersonGenerator) beyondProjection(date time.Time) bool {
+	return date.After(g.observedUntil())
+}
+
+func (e *Engine) markProjectedEvents() {
+	for _, p := range e.tree.GetAllPersons() {
+		p.Projected = p.BirthDate.After(e.tree.AsOf)
+		for i := range p.Events {
+			p.Events[i].Projected = p.Events[i].Date.After(e.tree.AsOf)
+		}
+	}
+}
@@ -1,5 +1,7 @@
 package generator
 
+import "github.com/familytree-generator/internal/data"
+
 const (
 	maxHumanAgeYears = 103
 
@@ -7,4 +9,38 @@ const (
 	maxMotherAgeAtBirth = 50
 	minFatherAgeAtBirth = 18
 	maxFatherAgeAtBirth = 80
+
+	maxUnionsPerPerson = 3
+	maxRemarriageAge   = 65
+
+	maxSingleParentChildren = 2
+
+	legalAdultAge             = 18
+	stepParentAdoptionShare   = 0.15
+	absentParentAdoptionShare = 0.4
+	minGuardianAge            = 21
+	maxGuardianAge            = 75
+
+	defaultMaxPersons = 2000
+
+	MaxCollateralDepth = 4
+
+	premaritalBirthLeadYears = 2
+	minBirthIntervalMonths   = 12
+
+	sameSexUnionShare = 0.03
+
+	heightSDMen        = 7.0
+	heightSDWomen      = 6.3
+	heightHeritability = 0.8
+
+	educationCohortAge    = 15
+	educationTransmission = 0.5
 )
+
+func ProfileLimits(profile data.Profile) (maxAncestors, minStartYear int) {
+	if data.ParseProfile(string(profile)) == data.ProfileEarlyModern {
+		return 15, data.EarlyModernFrom
+	}
+	return 10, 1800
+}
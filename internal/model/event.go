@@ -7,13 +7,18 @@ import (
 type EventType string
 
 const (
-	EventBirth      EventType = "birth"
-	EventDeath      EventType = "death"
-	EventMarriage   EventType = "marriage"
-	EventDivorce    EventType = "divorce"
-	EventMigration  EventType = "migration"
-	EventGraduation EventType = "graduation"
-	EventRetirement EventType = "retirement"
+	EventBirth        EventType = "birth"
+	EventDeath        EventType = "death"
+	EventMarriage     EventType = "marriage"
+	EventDivorce      EventType = "divorce"
+	EventCivilUnion   EventType = "civil_partnership"
+	EventCohabitation EventType = "cohabitation"
+	EventSeparation   EventType = "separation"
+	EventMigration    EventType = "migration"
+	EventGraduation   EventType = "graduation"
+	EventRetirement   EventType = "retirement"
+	EventAdoption     EventType = "adoption"
+	EventWidowhood    EventType = "widowhood"
 )
 
 type LifeEvent struct {
@@ -22,6 +27,7 @@ type LifeEvent struct {
 	Location    string    `json:"location,omitempty"`
 	Description string    `json:"description,omitempty"`
 	RelatedID   string    `json:"related_id,omitempty"`
+	Projected   bool      `json:"projected,omitempty"`
 }
 
 func NewLifeEvent(eventType EventType, date time.Time, location string) LifeEvent {
@@ -4,39 +4,95 @@ import (
 	"time"
 )
 
+type UnionType string
+
+const (
+	UnionMarriage         UnionType = "marriage"
+	UnionCivilPartnership UnionType = "civil_partnership"
+	UnionCohabitation     UnionType = "cohabitation"
+	UnionNone             UnionType = "none"
+)
+
 type Family struct {
-	ID          string     `json:"id"`
-	HusbandID   *string    `json:"husband_id,omitempty"`
-	WifeID      *string    `json:"wife_id,omitempty"`
-	ChildrenIDs []string   `json:"children_ids"`
-	MarriedDate time.Time  `json:"married_date"`
-	DivorceDate *time.Time `json:"divorce_date,omitempty"`
+	ID             string     `json:"id"`
+	PartnerIDs     []string   `json:"partner_ids"`
+	ChildrenIDs    []string   `json:"children_ids"`
+	UnionType      UnionType  `json:"union_type"`
+	SameSex        bool       `json:"same_sex,omitempty"`
+	UnionDate      *time.Time `json:"union_date,omitempty"`
+	DivorceDate    *time.Time `json:"divorce_date,omitempty"`
+	SeparationDate *time.Time `json:"separation_date,omitempty"`
 }
 
-func NewFamily(id string, marriedDate time.Time) *Family {
+func NewFamily(id string, unionType UnionType, unionDate *time.Time) *Family {
 	return &Family{
 		ID:          id,
+		PartnerIDs:  make([]string, 0, 2),
 		ChildrenIDs: make([]string, 0),
-		MarriedDate: marriedDate,
+		UnionType:   unionType,
+		UnionDate:   unionDate,
 	}
 }
 
-func (f *Family) SetHusband(id string) {
-	f.HusbandID = &id
+func (f *Family) AddPartner(id string) {
+	f.PartnerIDs = append(f.PartnerIDs, id)
+}
+
+func (f *Family) HasPartner(id string) bool {
+	for _, partnerID := range f.PartnerIDs {
+		if partnerID == id {
+			return true
+		}
+	}
+	return false
 }
 
-func (f *Family) SetWife(id string) {
-	f.WifeID = &id
+func (f *Family) IsCouple() bool {
+	return len(f.PartnerIDs) == 2
 }
 
 func (f *Family) AddChild(id string) {
 	f.ChildrenIDs = append(f.ChildrenIDs, id)
 }
 
+func (f *Family) IsMarriage() bool {
+	return f.UnionType == UnionMarriage
+}
+
+func (f *Family) MarriedBy(date time.Time) bool {
+	return f.IsMarriage() && f.UnionDate != nil && !date.Before(*f.UnionDate)
+}
+
+func (f *Family) IsLegalUnion() bool {
+	return f.UnionType == UnionMarriage || f.UnionType == UnionCivilPartnership
+}
+
+func (f *Family) IsSingleParent() bool {
+	return f.UnionType == UnionNone
+}
+
 func (f *Family) IsDivorced() bool {
 	return f.DivorceDate != nil
 }
 
+func (f *Family) IsSeparated() bool {
+	return f.SeparationDate != nil
+}
+
+func (f *Family) EndDate() *time.Time {
+	if f.DivorceDate != nil {
+		return f.DivorceDate
+	}
+	return f.SeparationDate
+}
+
+func (f *Family) YearsToDivorce() float64 {
+	if f.UnionDate == nil || f.DivorceDate == nil {
+		return 0
+	}
+	return f.DivorceDate.Sub(*f.UnionDate).Hours() / 24 / 365.25
+}
+
 func (f *Family) ChildCount() int {
 	return len(f.ChildrenIDs)
 }
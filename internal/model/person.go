@@ -1,6 +1,7 @@
 package model
 
 import (
+	"sort"
 	"time"
 )
 
@@ -52,26 +53,72 @@ const (
 	Remarried MaritalStatus = "remarried"
 )
 
+type ParentKind string
+
+const (
+	BiologicalParent ParentKind = "biological"
+	AdoptiveParent   ParentKind = "adoptive"
+	StepParent       ParentKind = "step"
+	FosterParent     ParentKind = "foster"
+)
+
+type ParentLink struct {
+	ParentID  string     `json:"parent_id"`
+	Kind      ParentKind `json:"kind"`
+	StartDate *time.Time `json:"start_date,omitempty"`
+	EndDate   *time.Time `json:"end_date,omitempty"`
+}
+
+func (l ParentLink) ActiveAt(date time.Time) bool {
+	if l.StartDate != nil && l.StartDate.After(date) {
+		return false
+	}
+	return l.EndDate == nil || l.EndDate.After(date)
+}
+
+type Zygosity string
+
+const (
+	Identical Zygosity = "identical"
+	Fraternal Zygosity = "fraternal"
+)
+
 type Person struct {
-	ID        string `json:"id"`
-	FirstName string `json:"first_name"`
-	LastName  string `json:"last_name"`
-	Gender    Gender `json:"gender"`
+	ID          string   `json:"id"`
+	FirstName   string   `json:"first_name"`
+	GivenNames  []string `json:"given_names,omitempty"`
+	CallName    string   `json:"call_name,omitempty"`
+	Patronymic  string   `json:"patronymic,omitempty"`
+	LastName    string   `json:"last_name"`
+	BirthName   string   `json:"birth_name,omitempty"`
+	MarriedName string   `json:"married_name,omitempty"`
+	Suffix      string   `json:"suffix,omitempty"`
+	Gender      Gender   `json:"gender"`
+
+	NativeFirstName  string `json:"native_first_name,omitempty"`
+	NativePatronymic string `json:"native_patronymic,omitempty"`
+	NativeLastName   string `json:"native_last_name,omitempty"`
+	NameScript       string `json:"name_script,omitempty"`
 
 	BirthDate      time.Time  `json:"birth_date"`
 	DeathDate      *time.Time `json:"death_date,omitempty"`
 	BirthCountry   string     `json:"birth_country"`
 	CurrentCountry string     `json:"current_country"`
 
-	FatherID    *string  `json:"father_id,omitempty"`
-	MotherID    *string  `json:"mother_id,omitempty"`
-	SpouseIDs   []string `json:"spouse_ids,omitempty"`
-	ChildrenIDs []string `json:"children_ids,omitempty"`
+	FatherID    *string      `json:"father_id,omitempty"`
+	MotherID    *string      `json:"mother_id,omitempty"`
+	NamesakeID  *string      `json:"namesake_id,omitempty"`
+	Parents     []ParentLink `json:"parents,omitempty"`
+	SpouseIDs   []string     `json:"spouse_ids,omitempty"`
+	ChildrenIDs []string     `json:"children_ids,omitempty"`
+	TwinIDs     []string     `json:"twin_ids,omitempty"`
+	Zygosity    Zygosity     `json:"zygosity,omitempty"`
 
 	Education    EducationLevel   `json:"education"`
 	Employment   EmploymentStatus `json:"employment"`
 	Health       HealthProfile    `json:"health"`
 	Underweight  bool             `json:"underweight,omitempty"`
+	Height       float64          `json:"height_cm,omitempty"`
 	Residence    ResidenceType    `json:"residence,omitempty"`
 	GDPPerCapita float64          `json:"gdp_per_capita,omitempty"`
 	WealthIndex  float64          `json:"wealth_index,omitempty"`
@@ -83,8 +130,10 @@ type Person struct {
 	NumberOfChildren    int           `json:"number_of_children"`
 	IsSingleParent      bool          `json:"is_single_parent,omitempty"`
 	BornOutsideMarriage bool          `json:"born_outside_marriage,omitempty"`
+	Adopted             bool          `json:"adopted,omitempty"`
 
-	Events []LifeEvent `json:"events,omitempty"`
+	Events    []LifeEvent `json:"events,omitempty"`
+	Projected bool        `json:"projected,omitempty"`
 
 	Generation int `json:"generation"`
 }
@@ -93,6 +142,10 @@ func (p *Person) IsAlive() bool {
 	return p.DeathDate == nil
 }
 
+func (p *Person) AliveAt(date time.Time) bool {
+	return !p.BirthDate.After(date) && (p.DeathDate == nil || p.DeathDate.After(date))
+}
+
 func (p *Person) Age(at time.Time) int {
 	endDate := at
 	if p.DeathDate != nil && p.DeathDate.Before(at) {
@@ -109,7 +162,110 @@ func (p *Person) AgeAtDeath() int {
 }
 
 func (p *Person) FullName() string {
-	return p.FirstName + " " + p.LastName
+	name := p.FirstName + " " + p.LastName
+	if p.Patronymic != "" {
+		name = p.FirstName + " " + p.Patronymic + " " + p.LastName
+	}
+	if p.Suffix != "" {
+		name += " " + p.Suffix
+	}
+	return name
+}
+
+func (p *Person) CountryAt(date time.Time) string {
+	country := p.BirthCountry
+	var latest time.Time
+	for _, event := range p.Events {
+		if event.Type != EventMigration || event.Date.After(date) || event.Date.Before(latest) {
+			continue
+		}
+		country = event.Location
+		latest = event.Date
+	}
+	return country
+}
+
+func (p *Person) NativeFullName() string {
+	if p.NativeFirstName == "" && p.NativeLastName == "" {
+		return ""
+	}
+
+	first, last := p.NativeFirstName, p.NativeLastName
+	if first == "" {
+		first = p.FirstName
+	}
+	if last == "" {
+		last = p.LastName
+	}
+
+	switch p.NameScript {
+	case "Jpan", "Kore", "Hang", "Hans", "Hant", "Hani":
+		if p.NativeFirstName == "" || p.NativeLastName == "" {
+			return last + " " + first
+		}
+		return last + first
+	}
+	if p.NativePatronymic != "" {
+		return first + " " + p.NativePatronymic + " " + last
+	}
+	return first + " " + last
+}
+
+func (p *Person) MaritalStatusAt(date time.Time) MaritalStatus {
+	if p.DeathDate != nil && p.DeathDate.Before(date) {
+		date = *p.DeathDate
+	}
+
+	events := make([]LifeEvent, 0, len(p.Events))
+	for _, event := range p.Events {
+		if !event.Date.After(date) {
+			events = append(events, event)
+		}
+	}
+	sort.SliceStable(events, func(i, j int) bool {
+		return events[i].Date.Before(events[j].Date)
+	})
+
+	status := Single
+	unions := 0
+	for _, event := range events {
+		switch event.Type {
+		case EventMarriage, EventCivilUnion:
+			unions++
+			status = Married
+			if unions > 1 {
+				status = Remarried
+			}
+		case EventDivorce:
+			status = Divorced
+		case EventWidowhood:
+			status = Widowed
+		}
+	}
+	return status
+}
+
+func (p *Person) AddParent(parentID string, kind ParentKind, since *time.Time) {
+	p.Parents = append(p.Parents, ParentLink{ParentID: parentID, Kind: kind, StartDate: since})
+}
+
+func (p *Person) HasParent(parentID string) bool {
+	for _, link := range p.Parents {
+		if link.ParentID == parentID {
+			return true
+		}
+	}
+	return false
+}
+
+func (p *Person) ParentIDs(filter RelationFilter) []string {
+	ids := make([]string, 0, len(p.Parents))
+	for _, link := range p.Parents {
+		if filter.Includes(link.Kind) {
+			ids = append(ids, link.ParentID)
+		}
+	}
+	return ids
 }
 
 func yearsBetween(start, end time.Time) int {
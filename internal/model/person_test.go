@@ -0,0 +1,65 @@
+package model
+
+import (
+	"testing"
+	"time"
+)
+
+func TestMaritalStatusAt(t *testing.T) {
+	married := date(1980, time.May, 10)
+	divorced := date(1990, time.March, 1)
+	remarried := date(1993, time.July, 20)
+	widowed := date(2005, time.January, 5)
+	thirdUnion := date(2008, time.September, 1)
+
+	person := newTestPerson("person", Female, 1955)
+	person.Events = []LifeEvent{
+		NewLifeEvent(EventBirth, person.BirthDate, "germany"),
+		NewLifeEvent(EventWidowhood, widowed, "germany"),
+		NewLifeEvent(EventMarriage, remarried, "germany"),
+		NewLifeEvent(EventDivorce, divorced, "germany"),
+		NewLifeEvent(EventMarriage, married, "germany"),
+	}
+
+	tests := []struct {
+		name string
+		at   time.Time
+		want MaritalStatus
+	}{
+		{"before first marriage", married.AddDate(0, 0, -1), Single},
+		{"on wedding day", married, Married},
+		{"married", date(1985, time.January, 1), Married},
+		{"divorced", divorced, Divorced},
+		{"remarried after divorce", date(2000, time.January, 1), Remarried},
+		{"widowed", widowed, Widowed},
+		{"still widowed", thirdUnion.AddDate(0, 0, -1), Widowed},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := person.MaritalStatusAt(tt.at); got != tt.want {
+				t.Errorf("MaritalStatusAt(%s) = %s, want %s", tt.at.Format("2006-01-02"), got, tt.want)
+			}
+		})
+	}
+
+	person.Events = append(person.Events, NewLifeEvent(EventCivilUnion, thirdUnion, "germany"))
+	if got := person.MaritalStatusAt(date(2010, time.January, 1)); got != Remarried {
+		t.Errorf("MaritalStatusAt after a civil union following widowhood = %s, want %s", got, Remarried)
+	}
+}
+
+func TestMaritalStatusAtStopsAtDeath(t *testing.T) {
+	death := date(2000, time.February, 1)
+
+	person := newTestPerson("person", Male, 1950)
+	person.DeathDate = &death
+	person.Events = []LifeEvent{
+		NewLifeEvent(EventMarriage, date(1975, time.June, 1), "germany"),
+		NewLifeEvent(EventDivorce, date(2001, time.June, 1), "germany"),
+	}
+
+	if got := person.MaritalStatusAt(date(2020, time.January, 1)); got != Married {
+		t.Errorf("MaritalStatusAt after death = %s, want the status at death %s", got, Married)
+	}
+}
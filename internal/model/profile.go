@@ -0,0 +1,22 @@
+package model
+
+type DemographicProfile struct {
+	Name        string               `json:"name"`
+	FromYear    int                  `json:"from_year"`
+	UntilYear   int                  `json:"until_year"`
+	BlendFrom   int                  `json:"blend_from"`
+	Countries   []CountryAssumptions `json:"countries"`
+	Assumptions []string             `json:"assumptions"`
+}
+
+type CountryAssumptions struct {
+	Country               string  `json:"country"`
+	MarriagePattern       string  `json:"marriage_pattern"`
+	WomenMarriageAge      float64 `json:"women_marriage_age"`
+	MarriageRate          float64 `json:"marriage_rate"`
+	TotalFertility        float64 `json:"total_fertility"`
+	YouthMortality        float64 `json:"youth_mortality"`
+	BirthsOutsideMarriage float64 `json:"births_outside_marriage"`
+	SingleParentShare     float64 `json:"single_parent_share"`
+	NameGroup             string  `json:"name_group,omitempty"`
+}
@@ -0,0 +1,175 @@
+package model
+
+import (
+	"sort"
+	"time"
+)
+
+const adultAge = 18
+
+type PersonState struct {
+	ID            string        `json:"id"`
+	Name          string        `json:"name"`
+	Gender        Gender        `json:"gender"`
+	Alive         bool          `json:"alive"`
+	Age           int           `json:"age"`
+	MaritalStatus MaritalStatus `json:"marital_status"`
+	Country       string        `json:"country"`
+	HouseholdID   string        `json:"household_id,omitempty"`
+}
+
+type Household struct {
+	ID        string   `json:"id"`
+	FamilyID  string   `json:"family_id,omitempty"`
+	MemberIDs []string `json:"member_ids"`
+}
+
+type Snapshot struct {
+	TreeID     string        `json:"tree_id"`
+	Date       time.Time     `json:"date"`
+	Living     int           `json:"living"`
+	Deceased   int           `json:"deceased"`
+	Persons    []PersonState `json:"persons"`
+	Households []Household   `json:"households"`
+}
+
+func (t *FamilyTree) SnapshotAt(date time.Time) *Snapshot {
+	persons := make([]*Person, 0, len(t.Persons))
+	for _, p := range t.Persons {
+		if !p.BirthDate.After(date) {
+			persons = append(persons, p)
+		}
+	}
+	sort.Slice(persons, func(i, j int) bool {
+		return persons[i].ID < persons[j].ID
+	})
+
+	households := t.householdsAt(date, persons)
+
+	snapshot := &Snapshot{
+		TreeID:     t.ID,
+		Date:       date,
+		Persons:    make([]PersonState, 0, len(persons)),
+		Households: make([]Household, 0, len(households)),
+	}
+
+	for _, p := range persons {
+		state := PersonState{
+			ID:            p.ID,
+			Name:          p.FullName(),
+			Gender:        p.Gender,
+			Alive:         p.AliveAt(date),
+			Age:           p.Age(date),
+			MaritalStatus: p.MaritalStatusAt(date),
+			Country:       p.CountryAt(date),
+		}
+		if state.Alive {
+			snapshot.Living++
+			if household := households[p.ID]; household != nil {
+				state.HouseholdID = household.ID
+			}
+		} else {
+			snapshot.Deceased++
+		}
+		snapshot.Persons = append(snapshot.Persons, state)
+	}
+
+	seen := make(map[string]bool)
+	for _, p := range persons {
+		household := households[p.ID]
+		if household == nil || seen[household.ID] {
+			continue
+		}
+		seen[household.ID] = true
+		snapshot.Households = append(snapshot.Households, *household)
+	}
+	sort.Slice(snapshot.Households, func(i, j int) bool {
+		return snapshot.Households[i].ID < snapshot.Households[j].ID
+	})
+
+	return snapshot
+}
+
+func (t *FamilyTree) householdsAt(date time.Time, persons []*Person) map[string]*Household {
+	households := make(map[string]*Household)
+
+	families := t.GetAllFamilies()
+	sort.Slice(families, func(i, j int) bool {
+		return families[i].ID < families[j].ID
+	})
+	for _, family := range families {
+		if !family.IsCouple() || family.UnionDate == nil || family.UnionDate.After(date) {
+			continue
+		}
+		if end := family.EndDate(); end != nil && !end.After(date) {
+			continue
+		}
+		partner, other := t.GetPerson(family.PartnerIDs[0]), t.GetPerson(family.PartnerIDs[1])
+		if partner == nil || other == nil || !partner.AliveAt(date) || !other.AliveAt(date) {
+			continue
+		}
+		if households[partner.ID] != nil || households[other.ID] != nil {
+			continue
+		}
+		household := &Household{ID: "H-" + family.ID, FamilyID: family.ID, MemberIDs: []string{partner.ID, other.ID}}
+		households[partner.ID] = household
+		households[other.ID] = household
+	}
+
+	var minors []*Person
+	for _, p := range persons {
+		if !p.AliveAt(date) || households[p.ID] != nil {
+			continue
+		}
+		if p.Age(date) < adultAge {
+			minors = append(minors, p)
+			continue
+		}
+		households[p.ID] = &Household{ID: "H-" + p.ID, MemberIDs: []string{p.ID}}
+	}
+
+	sort.SliceStable(minors, func(i, j int) bool {
+		return minors[i].BirthDate.Before(minors[j].BirthDate)
+	})
+	for _, child := range minors {
+		household := t.caregiverHousehold(child, date, households)
+		if household == nil {
+			household = &Household{ID: "H-" + child.ID}
+		}
+		household.MemberIDs = append(household.MemberIDs, child.ID)
+		households[child.ID] = household
+	}
+
+	return households
+}
+
+func (t *FamilyTree) caregiverHousehold(child *Person, date time.Time, households map[string]*Household) *Household {
+	var foster, legal, step []*Person
+	for _, link := range child.Parents {
+		if !link.ActiveAt(date) {
+			continue
+		}
+		parent := t.GetPerson(link.ParentID)
+		if parent == nil || !parent.AliveAt(date) || households[parent.ID] == nil {
+			continue
+		}
+		switch link.Kind {
+		case FosterParent:
+			foster = append(foster, parent)
+		case StepParent:
+			step = append(step, parent)
+		default:
+			legal = append(legal, parent)
+		}
+	}
+	sort.SliceStable(legal, func(i, j int) bool {
+		return legal[i].Gender == Female && legal[j].Gender != Female
+	})
+
+	for _, group := range [][]*Person{foster, legal, step} {
+		if len(group) > 0 {
+			return households[group[0].ID]
+		}
+	}
+	return nil
+}
@@ -0,0 +1,118 @@
+package model
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func snapshotTree() *FamilyTree {
+	tree := NewFamilyTree("tree", "germany", 0, 1, 1)
+	tree.AsOf = date(2020, time.December, 31)
+
+	husband := newTestPerson("husband", Male, 1960)
+	wife := newTestPerson("wife", Female, 1962)
+	child := newTestPerson("child", Female, 1995)
+	death := date(2010, time.April, 1)
+	husband.DeathDate = &death
+
+	union := date(1990, time.June, 1)
+	for _, pair := range [][2]*Person{{husband, wife}, {wife, husband}} {
+		pair[0].Events = append(pair[0].Events, NewLifeEvent(EventMarriage, union, "germany").WithRelatedID(pair[1].ID))
+	}
+	wife.Events = append(wife.Events, NewLifeEvent(EventWidowhood, death, "germany").WithRelatedID(husband.ID))
+	child.AddParent(husband.ID, BiologicalParent, nil)
+	child.AddParent(wife.ID, BiologicalParent, nil)
+
+	family := NewFamily("F1", UnionMarriage, &union)
+	family.AddPartner(husband.ID)
+	family.AddPartner(wife.ID)
+	family.AddChild(child.ID)
+
+	tree.SetRootPerson(wife)
+	tree.AddPerson(husband)
+	tree.AddPerson(child)
+	tree.AddFamily(family)
+	return tree
+}
+
+func TestSnapshotAt(t *testing.T) {
+	tree := snapshotTree()
+
+	tests := []struct {
+		name       string
+		at         time.Time
+		living     int
+		deceased   int
+		status     map[string]MaritalStatus
+		households map[string][]string
+	}{
+		{
+			name:       "before the child is born",
+			at:         date(1992, time.January, 1),
+			living:     2,
+			status:     map[string]MaritalStatus{"husband": Married, "wife": Married},
+			households: map[string][]string{"H-F1": {"husband", "wife"}},
+		},
+		{
+			name:       "couple with a child",
+			at:         date(2000, time.January, 1),
+			living:     3,
+			status:     map[string]MaritalStatus{"child": Single, "husband": Married, "wife": Married},
+			households: map[string][]string{"H-F1": {"husband", "wife", "child"}},
+		},
+		{
+			name:       "widow with a minor child",
+			at:         date(2011, time.January, 1),
+			living:     2,
+			deceased:   1,
+			status:     map[string]MaritalStatus{"child": Single, "husband": Married, "wife": Widowed},
+			households: map[string][]string{"H-wife": {"wife", "child"}},
+		},
+		{
+			name:       "adult child moved out",
+			at:         date(2015, time.January, 1),
+			living:     2,
+			deceased:   1,
+			status:     map[string]MaritalStatus{"child": Single, "husband": Married, "wife": Widowed},
+			households: map[string][]string{"H-child": {"child"}, "H-wife": {"wife"}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			snapshot := tree.SnapshotAt(tt.at)
+			if snapshot.Living != tt.living || snapshot.Deceased != tt.deceased {
+				t.Errorf("living, deceased = %d, %d, want %d, %d", snapshot.Living, snapshot.Deceased, tt.living, tt.deceased)
+			}
+
+			status := make(map[string]MaritalStatus)
+			for _, state := range snapshot.Persons {
+				status[state.ID] = state.MaritalStatus
+			}
+			if !reflect.DeepEqual(status, tt.status) {
+				t.Errorf("marital status = %v, want %v", status, tt.status)
+			}
+
+			households := make(map[string][]string)
+			for _, household := range snapshot.Households {
+				households[household.ID] = household.MemberIDs
+			}
+			if !reflect.DeepEqual(households, tt.households) {
+				t.Errorf("households = %v, want %v", households, tt.households)
+			}
+		})
+	}
+}
+
+func TestObservedUntil(t *testing.T) {
+	tree := snapshotTree()
+	if got := tree.ObservedUntil(); !got.Equal(tree.AsOf) {
+		t.Errorf("ObservedUntil without projection = %v, want the as-of date %v", got, tree.AsOf)
+	}
+
+	tree.ProjectUntil = 2060
+	if got, want := tree.ObservedUntil(), date(2060, time.December, 31); !got.Equal(want) {
+		t.Errorf("ObservedUntil with projection = %v, want %v", got, want)
+	}
+}
@@ -1,30 +1,71 @@
 package model
 
 import (
+	"sort"
 	"time"
 )
 
-type FamilyTree struct {
-	ID           string             `json:"id"`
-	RootPersonID string             `json:"root_person_id"`
-	Persons      map[string]*Person `json:"persons"`
-	Families     map[string]*Family `json:"families"`
-	Generations  int                `json:"generations"`
-	Country      string             `json:"country"`
-	GeneratedAt  time.Time          `json:"generated_at"`
-	Seed         int64              `json:"seed"`
+type RelationFilter []ParentKind
+
+var (
+	BiologicalLineage = RelationFilter{BiologicalParent}
+	LegalLineage      = RelationFilter{BiologicalParent, AdoptiveParent}
+	AllRelations      = RelationFilter{BiologicalParent, AdoptiveParent, StepParent, FosterParent}
+)
+
+func (f RelationFilter) Includes(kind ParentKind) bool {
+	for _, allowed := range f {
+		if allowed == kind {
+			return true
+		}
+	}
+	return false
 }
 
-func NewFamilyTree(id, country string, generations int, seed int64) *FamilyTree {
+type FamilyTree struct {
+	ID                    string              `json:"id"`
+	RootPersonID          string              `json:"root_person_id"`
+	Persons               map[string]*Person  `json:"persons"`
+	Families              map[string]*Family  `json:"families"`
+	Generations           int                 `json:"generations"`
+	AncestorGenerations   int                 `json:"ancestor_generations"`
+	DescendantGenerations int                 `json:"descendant_generations"`
+	AsOf                  time.Time           `json:"as_of"`
+	Scenario              string              `json:"scenario,omitempty"`
+	ProjectUntil          int                 `json:"project_until,omitempty"`
+	Profile               *DemographicProfile `json:"profile,omitempty"`
+	Country               string              `json:"country"`
+	Region                string              `json:"region,omitempty"`
+	GeneratedAt           time.Time           `json:"generated_at"`
+	Seed                  int64               `json:"seed"`
+}
+
+func NewFamilyTree(id, country string, ancestorGenerations, descendantGenerations int, seed int64) *FamilyTree {
 	return &FamilyTree{
-		ID:          id,
-		Persons:     make(map[string]*Person),
-		Families:    make(map[string]*Family),
-		Generations: generations,
-		Country:     country,
-		GeneratedAt: time.Now(),
-		Seed:        seed,
+		ID:                    id,
+		Persons:               make(map[string]*Person),
+		Families:              make(map[string]*Family),
+		Generations:           ancestorGenerations + descendantGenerations + 1,
+		AncestorGenerations:   ancestorGenerations,
+		DescendantGenerations: descendantGenerations,
+		Country:               country,
+		GeneratedAt:           time.Now(),
+		Seed:                  seed,
+	}
+}
+
+func (t *FamilyTree) ReferenceDate() time.Time {
+	if t.AsOf.IsZero() {
+		return time.Now()
 	}
+	return t.AsOf
+}
+
+func (t *FamilyTree) ObservedUntil() time.Time {
+	if t.ProjectUntil > 0 {
+		return time.Date(t.ProjectUntil, time.December, 31, 0, 0, 0, 0, time.UTC)
+	}
+	return t.ReferenceDate()
 }
 
 func (t *FamilyTree) SetRootPerson(p *Person) {
@@ -60,7 +101,7 @@ func (t *FamilyTree) FamilyCount() int {
 	return len(t.Families)
 }
 
-func (t *FamilyTree) GetAncestors(personID string) []*Person {
+func (t *FamilyTree) GetAncestors(personID string, filter RelationFilter) []*Person {
 	ancestors := make([]*Person, 0)
 	person := t.GetPerson(personID)
 	if person == nil {
@@ -68,30 +109,25 @@ func (t *FamilyTree) GetAncestors(personID string) []*Person {
 	}
 
 	visited := make(map[string]bool)
-	t.collectAncestors(person, &ancestors, visited)
+	t.collectAncestors(person, filter, &ancestors, visited)
 	return ancestors
 }
 
-func (t *FamilyTree) collectAncestors(p *Person, ancestors *[]*Person, visited map[string]bool) {
-	if p.FatherID != nil && !visited[*p.FatherID] {
-		father := t.GetPerson(*p.FatherID)
-		if father != nil {
-			visited[*p.FatherID] = true
-			*ancestors = append(*ancestors, father)
-			t.collectAncestors(father, ancestors, visited)
+func (t *FamilyTree) collectAncestors(p *Person, filter RelationFilter, ancestors *[]*Person, visited map[string]bool) {
+	for _, parentID := range p.ParentIDs(filter) {
+		if visited[parentID] {
+			continue
 		}
-	}
-	if p.MotherID != nil && !visited[*p.MotherID] {
-		mother := t.GetPerson(*p.MotherID)
-		if mother != nil {
-			visited[*p.MotherID] = true
-			*ancestors = append(*ancestors, mother)
-			t.collectAncestors(mother, ancestors, visited)
+		parent := t.GetPerson(parentID)
+		if parent != nil {
+			visited[parentID] = true
+			*ancestors = append(*ancestors, parent)
+			t.collectAncestors(parent, filter, ancestors, visited)
 		}
 	}
 }
 
-func (t *FamilyTree) GetDescendants(personID string) []*Person {
+func (t *FamilyTree) GetDescendants(personID string, filter RelationFilter) []*Person {
 	descendants := make([]*Person, 0)
 	person := t.GetPerson(personID)
 	if person == nil {
@@ -99,19 +135,31 @@ func (t *FamilyTree) GetDescendants(personID string) []*Person {
 	}
 
 	visited := make(map[string]bool)
-	t.collectDescendants(person, &descendants, visited)
+	t.collectDescendants(person, t.childrenIndex(filter), &descendants, visited)
 	return descendants
 }
 
-func (t *FamilyTree) collectDescendants(p *Person, descendants *[]*Person, visited map[string]bool) {
-	for _, childID := range p.ChildrenIDs {
-		if !visited[childID] {
-			child := t.GetPerson(childID)
-			if child != nil {
-				visited[childID] = true
-				*descendants = append(*descendants, child)
-				t.collectDescendants(child, descendants, visited)
-			}
+func (t *FamilyTree) childrenIndex(filter RelationFilter) map[string][]*Person {
+	index := make(map[string][]*Person)
+	for _, p := range t.Persons {
+		for _, parentID := range p.ParentIDs(filter) {
+			index[parentID] = append(index[parentID], p)
+		}
+	}
+	for _, children := range index {
+		sort.Slice(children, func(i, j int) bool {
+			return children[i].ID < children[j].ID
+		})
+	}
+	return index
+}
+
+func (t *FamilyTree) collectDescendants(p *Person, index map[string][]*Person, descendants *[]*Person, visited map[string]bool) {
+	for _, child := range index[p.ID] {
+		if !visited[child.ID] {
+			visited[child.ID] = true
+			*descendants = append(*descendants, child)
+			t.collectDescendants(child, index, descendants, visited)
 		}
 	}
 }
@@ -123,15 +171,22 @@ func (t *FamilyTree) GetSiblings(personID string) []*Person {
 		return siblings
 	}
 
-	if person.FatherID != nil {
-		father := t.GetPerson(*person.FatherID)
-		if father != nil {
-			for _, childID := range father.ChildrenIDs {
-				if childID != personID {
-					if child := t.GetPerson(childID); child != nil {
-						siblings = append(siblings, child)
-					}
-				}
+	seen := map[string]bool{personID: true}
+	for _, parentID := range []*string{person.FatherID, person.MotherID} {
+		if parentID == nil {
+			continue
+		}
+		parent := t.GetPerson(*parentID)
+		if parent == nil {
+			continue
+		}
+		for _, childID := range parent.ChildrenIDs {
+			if seen[childID] {
+				continue
+			}
+			seen[childID] = true
+			if child := t.GetPerson(childID); child != nil {
+				siblings = append(siblings, child)
 			}
 		}
 	}
@@ -139,6 +194,28 @@ func (t *FamilyTree) GetSiblings(personID string) []*Person {
 	return siblings
 }
 
+func (t *FamilyTree) GetHalfSiblings(personID string) []*Person {
+	halfSiblings := make([]*Person, 0)
+	person := t.GetPerson(personID)
+	if person == nil {
+		return halfSiblings
+	}
+
+	for _, sibling := range t.GetSiblings(personID) {
+		if !sameParent(person.FatherID, sibling.FatherID) || !sameParent(person.MotherID, sibling.MotherID) {
+			halfSiblings = append(halfSiblings, sibling)
+		}
+	}
+	return halfSiblings
+}
+
+func sameParent(a, b *string) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return *a == *b
+}
+
 func (t *FamilyTree) GetGeneration(gen int) []*Person {
 	persons := make([]*Person, 0)
 	for _, p := range t.Persons {
@@ -0,0 +1,115 @@
+package model
+
+import (
+	"reflect"
+	"sort"
+	"testing"
+	"time"
+)
+
+func date(year int, month time.Month, day int) time.Time {
+	return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+}
+
+func newTestPerson(id string, gender Gender, birthYear int) *Person {
+	return NewPerson(id, id, "Test", gender, date(birthYear, time.January, 1), "germany", 0)
+}
+
+func personIDs(persons []*Person) []string {
+	ids := make([]string, 0, len(persons))
+	for _, p := range persons {
+		ids = append(ids, p.ID)
+	}
+	sort.Strings(ids)
+	return ids
+}
+
+// lineageTree: grandfather and grandmother are the biological parents of
+// father; father and mother have a biological child and an adopted child;
+// stepfather is the child's step-parent and mother fosters another child.
+func lineageTree() *FamilyTree {
+	tree := NewFamilyTree("tree", "germany", 2, 1, 1)
+	persons := map[string]*Person{
+		"grandfather": newTestPerson("grandfather", Male, 1930),
+		"grandmother": newTestPerson("grandmother", Female, 1932),
+		"father":      newTestPerson("father", Male, 1960),
+		"mother":      newTestPerson("mother", Female, 1962),
+		"stepfather":  newTestPerson("stepfather", Male, 1958),
+		"child":       newTestPerson("child", Female, 1990),
+		"adoptee":     newTestPerson("adoptee", Male, 1992),
+		"foster":      newTestPerson("foster", Male, 2000),
+	}
+	for _, p := range persons {
+		tree.AddPerson(p)
+	}
+
+	adopted := date(1995, time.March, 1)
+	stepped := date(2001, time.June, 1)
+	fostered := date(2005, time.May, 1)
+	persons["father"].AddParent("grandfather", BiologicalParent, nil)
+	persons["father"].AddParent("grandmother", BiologicalParent, nil)
+	persons["child"].AddParent("father", BiologicalParent, nil)
+	persons["child"].AddParent("mother", BiologicalParent, nil)
+	persons["child"].AddParent("stepfather", StepParent, &stepped)
+	persons["adoptee"].AddParent("father", AdoptiveParent, &adopted)
+	persons["adoptee"].AddParent("mother", AdoptiveParent, &adopted)
+	persons["foster"].AddParent("mother", FosterParent, &fostered)
+
+	return tree
+}
+
+func TestGetAncestors(t *testing.T) {
+	tree := lineageTree()
+
+	tests := []struct {
+		name     string
+		personID string
+		filter   RelationFilter
+		want     []string
+	}{
+		{"biological child, biological lineage", "child", BiologicalLineage, []string{"father", "grandfather", "grandmother", "mother"}},
+		{"biological child, legal lineage", "child", LegalLineage, []string{"father", "grandfather", "grandmother", "mother"}},
+		{"biological child, all relations", "child", AllRelations, []string{"father", "grandfather", "grandmother", "mother", "stepfather"}},
+		{"adoptee, biological lineage", "adoptee", BiologicalLineage, []string{}},
+		{"adoptee, legal lineage", "adoptee", LegalLineage, []string{"father", "grandfather", "grandmother", "mother"}},
+		{"foster child, legal lineage", "foster", LegalLineage, []string{}},
+		{"foster child, all relations", "foster", AllRelations, []string{"mother"}},
+		{"unknown person", "nobody", AllRelations, []string{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := personIDs(tree.GetAncestors(tt.personID, tt.filter))
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("GetAncestors(%q) = %v, want %v", tt.personID, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGetDescendants(t *testing.T) {
+	tree := lineageTree()
+
+	tests := []struct {
+		name     string
+		personID string
+		filter   RelationFilter
+		want     []string
+	}{
+		{"grandfather, biological lineage", "grandfather", BiologicalLineage, []string{"child", "father"}},
+		{"grandfather, legal lineage", "grandfather", LegalLineage, []string{"adoptee", "child", "father"}},
+		{"mother, legal lineage", "mother", LegalLineage, []string{"adoptee", "child"}},
+		{"mother, all relations", "mother", AllRelations, []string{"adoptee", "child", "foster"}},
+		{"stepfather, legal lineage", "stepfather", LegalLineage, []string{}},
+		{"stepfather, all relations", "stepfather", AllRelations, []string{"child"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := personIDs(tree.GetDescendants(tt.personID, tt.filter))
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("GetDescendants(%q) = %v, want %v", tt.personID, got, tt.want)
+			}
+		})
+	}
+}
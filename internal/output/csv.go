@@ -4,7 +4,9 @@ import (
 	"encoding/csv"
 	"fmt"
 	"os"
+	"strconv"
 	"strings"
+	"time"
 
 	"github.com/familytree-generator/internal/model"
 )
@@ -24,8 +26,15 @@ func WriteCSV(tree *model.FamilyTree, filepath string) error {
 	header := []string{
 		"id",
 		"first_name",
+		"given_names",
+		"call_name",
+		"patronymic",
 		"last_name",
+		"birth_name",
+		"married_name",
+		"suffix",
 		"gender",
+		"marital_status",
 		"birth_date",
 		"death_date",
 		"birth_country",
@@ -39,15 +48,29 @@ func WriteCSV(tree *model.FamilyTree, filepath string) error {
 		"employment",
 		"alcohol_consumption",
 		"tobacco_use",
+		"born_outside_marriage",
+		"is_single_parent",
+		"adopted",
+		"height_cm",
+		"namesake_id",
+		"native_first_name",
+		"native_patronymic",
+		"native_last_name",
+		"name_script",
+		"twin_ids",
+		"zygosity",
+		"parents",
+		"projected",
+		"death_projected",
 	}
 
 	if err := writer.Write(header); err != nil {
 		return fmt.Errorf("writing header: %w", err)
 	}
 
-	
+	referenceDate := tree.ReferenceDate()
 	for _, person := range tree.GetAllPersons() {
-		row := personToRow(person)
+		row := personToRow(person, referenceDate)
 		if err := writer.Write(row); err != nil {
 			return fmt.Errorf("writing row for %s: %w", person.ID, err)
 		}
@@ -57,7 +80,7 @@ func WriteCSV(tree *model.FamilyTree, filepath string) error {
 }
 
 
-func personToRow(p *model.Person) []string {
+func personToRow(p *model.Person, referenceDate time.Time) []string {
 	deathDate := ""
 	if p.DeathDate != nil {
 		deathDate = p.DeathDate.Format("2006-01-02")
@@ -73,6 +96,11 @@ func personToRow(p *model.Person) []string {
 		motherID = *p.MotherID
 	}
 
+	namesakeID := ""
+	if p.NamesakeID != nil {
+		namesakeID = *p.NamesakeID
+	}
+
 	tobaccoUse := "false"
 	if p.Health.TobaccoUse {
 		tobaccoUse = "true"
@@ -81,8 +109,15 @@ func personToRow(p *model.Person) []string {
 	return []string{
 		p.ID,
 		p.FirstName,
+		strings.Join(p.GivenNames, ";"),
+		p.CallName,
+		p.Patronymic,
 		p.LastName,
+		p.BirthName,
+		p.MarriedName,
+		p.Suffix,
 		string(p.Gender),
+		string(p.MaritalStatusAt(referenceDate)),
 		p.BirthDate.Format("2006-01-02"),
 		deathDate,
 		p.BirthCountry,
@@ -96,7 +131,30 @@ func personToRow(p *model.Person) []string {
 		string(p.Employment),
 		fmt.Sprintf("%.1f", p.Health.AlcoholConsumption),
 		tobaccoUse,
+		strconv.FormatBool(p.BornOutsideMarriage),
+		strconv.FormatBool(p.IsSingleParent),
+		strconv.FormatBool(p.Adopted),
+		fmt.Sprintf("%.1f", p.Height),
+		namesakeID,
+		p.NativeFirstName,
+		p.NativePatronymic,
+		p.NativeLastName,
+		p.NameScript,
+		strings.Join(p.TwinIDs, ";"),
+		string(p.Zygosity),
+		parentLinks(p.Parents),
+		strconv.FormatBool(p.Projected),
+		strconv.FormatBool(p.DeathDate != nil && p.DeathDate.After(referenceDate)),
+	}
+}
+
+
+func parentLinks(links []model.ParentLink) string {
+	parts := make([]string, 0, len(links))
+	for _, link := range links {
+		parts = append(parts, link.ParentID+":"+string(link.Kind))
 	}
+	return strings.Join(parts, ";")
 }
 
 
@@ -113,10 +171,13 @@ func WriteFamiliesCSV(tree *model.FamilyTree, filepath string) error {
 	
 	header := []string{
 		"id",
-		"husband_id",
-		"wife_id",
-		"married_date",
+		"partner_ids",
+		"union_type",
+		"same_sex",
+		"union_date",
 		"divorce_date",
+		"separation_date",
+		"years_to_divorce",
 		"children_ids",
 		"children_count",
 	}
@@ -138,14 +199,9 @@ func WriteFamiliesCSV(tree *model.FamilyTree, filepath string) error {
 
 
 func familyToRow(f *model.Family) []string {
-	husbandID := ""
-	if f.HusbandID != nil {
-		husbandID = *f.HusbandID
-	}
-
-	wifeID := ""
-	if f.WifeID != nil {
-		wifeID = *f.WifeID
+	unionDate := ""
+	if f.UnionDate != nil {
+		unionDate = f.UnionDate.Format("2006-01-02")
 	}
 
 	divorceDate := ""
@@ -153,12 +209,25 @@ func familyToRow(f *model.Family) []string {
 		divorceDate = f.DivorceDate.Format("2006-01-02")
 	}
 
+	separationDate := ""
+	if f.SeparationDate != nil {
+		separationDate = f.SeparationDate.Format("2006-01-02")
+	}
+
+	yearsToDivorce := ""
+	if f.IsDivorced() {
+		yearsToDivorce = fmt.Sprintf("%.1f", f.YearsToDivorce())
+	}
+
 	return []string{
 		f.ID,
-		husbandID,
-		wifeID,
-		f.MarriedDate.Format("2006-01-02"),
+		strings.Join(f.PartnerIDs, ";"),
+		string(f.UnionType),
+		strconv.FormatBool(f.SameSex),
+		unionDate,
 		divorceDate,
+		separationDate,
+		yearsToDivorce,
 		strings.Join(f.ChildrenIDs, ";"),
 		fmt.Sprintf("%d", len(f.ChildrenIDs)),
 	}
@@ -4,7 +4,6 @@ import (
 	"encoding/json"
 	"fmt"
 	"os"
-	"time"
 
 	"github.com/familytree-generator/internal/model"
 )
@@ -50,74 +49,135 @@ func TreeToJSONCompact(tree *model.FamilyTree) ([]byte, error) {
 	return json.Marshal(tree)
 }
 
+const (
+	adultAge           = 18
+	completedFamilyAge = 45
+)
+
 type VisualizationData struct {
-	ID            string              `json:"id"`
-	RootID        string              `json:"root_id"`
-	Country       string              `json:"country"`
-	Generations   int                 `json:"generations"`
-	Seed          int64               `json:"seed"`
-	ReferenceYear int                 `json:"reference_year"`
-	Nodes         []VisualizationNode `json:"nodes"`
-	Edges         []VisualizationEdge `json:"edges"`
-	Stats         VisualizationStats  `json:"stats"`
+	ID            string                    `json:"id"`
+	RootID        string                    `json:"root_id"`
+	Country       string                    `json:"country"`
+	Region        string                    `json:"region,omitempty"`
+	Generations   int                       `json:"generations"`
+	Ancestors     int                       `json:"ancestor_generations"`
+	Descendants   int                       `json:"descendant_generations"`
+	Seed          int64                     `json:"seed"`
+	ReferenceYear int                       `json:"reference_year"`
+	AsOf          string                    `json:"as_of"`
+	Scenario      string                    `json:"scenario,omitempty"`
+	ProjectUntil  int                       `json:"project_until,omitempty"`
+	Profile       *model.DemographicProfile `json:"profile,omitempty"`
+	Nodes         []VisualizationNode       `json:"nodes"`
+	Edges         []VisualizationEdge       `json:"edges"`
+	Stats         VisualizationStats        `json:"stats"`
 }
 
 type VisualizationNode struct {
-	ID                  string  `json:"id"`
-	Name                string  `json:"name"`
-	FirstName           string  `json:"first_name"`
-	LastName            string  `json:"last_name"`
-	Gender              string  `json:"gender"`
-	BirthYear           int     `json:"birth_year"`
-	DeathYear           *int    `json:"death_year,omitempty"`
-	IsAlive             bool    `json:"is_alive"`
-	Generation          int     `json:"generation"`
-	MaritalStatus       string  `json:"marital_status"`
-	MarriageAge         int     `json:"marriage_age,omitempty"`
-	NumberOfChildren    int     `json:"number_of_children"`
-	Education           string  `json:"education"`
-	Employment          string  `json:"employment"`
-	AlcoholConsumption  float64 `json:"alcohol_consumption"`
-	TobaccoUse          bool    `json:"tobacco_use"`
-	BornOutsideMarriage bool    `json:"born_outside_marriage"`
-	IsSingleParent      bool    `json:"is_single_parent"`
-	Underweight         bool    `json:"underweight"`
-	Residence           string  `json:"residence"`
-	GDPPerCapita        float64 `json:"gdp_per_capita"`
-	WealthIndex         float64 `json:"wealth_index"`
-	FamilyWealth        float64 `json:"family_wealth"`
-	IsRich              bool    `json:"is_rich"`
-	Country             string  `json:"country"`
-	CurrentCountry      string  `json:"current_country"`
+	ID                  string   `json:"id"`
+	Name                string   `json:"name"`
+	FirstName           string   `json:"first_name"`
+	GivenNames          []string `json:"given_names,omitempty"`
+	CallName            string   `json:"call_name,omitempty"`
+	Patronymic          string   `json:"patronymic,omitempty"`
+	LastName            string   `json:"last_name"`
+	BirthName           string   `json:"birth_name,omitempty"`
+	MarriedName         string   `json:"married_name,omitempty"`
+	Suffix              string   `json:"suffix,omitempty"`
+	NativeName          string   `json:"native_name,omitempty"`
+	NativeFirstName     string   `json:"native_first_name,omitempty"`
+	NativePatronymic    string   `json:"native_patronymic,omitempty"`
+	NativeLastName      string   `json:"native_last_name,omitempty"`
+	NameScript          string   `json:"name_script,omitempty"`
+	NamesakeID          string   `json:"namesake_id,omitempty"`
+	TwinIDs             []string `json:"twin_ids,omitempty"`
+	Zygosity            string   `json:"zygosity,omitempty"`
+	Gender              string   `json:"gender"`
+	BirthYear           int      `json:"birth_year"`
+	DeathYear           *int     `json:"death_year,omitempty"`
+	IsAlive             bool     `json:"is_alive"`
+	Projected           bool     `json:"projected,omitempty"`
+	DeathProjected      bool     `json:"death_projected,omitempty"`
+	Generation          int      `json:"generation"`
+	MaritalStatus       string   `json:"marital_status"`
+	MarriageAge         int      `json:"marriage_age,omitempty"`
+	NumberOfChildren    int      `json:"number_of_children"`
+	Education           string   `json:"education"`
+	Employment          string   `json:"employment"`
+	AlcoholConsumption  float64  `json:"alcohol_consumption"`
+	TobaccoUse          bool     `json:"tobacco_use"`
+	BornOutsideMarriage bool     `json:"born_outside_marriage"`
+	IsSingleParent      bool     `json:"is_single_parent"`
+	Adopted             bool     `json:"adopted"`
+	Underweight         bool     `json:"underweight"`
+	Height              float64  `json:"height_cm"`
+	Residence           string   `json:"residence"`
+	GDPPerCapita        float64  `json:"gdp_per_capita"`
+	WealthIndex         float64  `json:"wealth_index"`
+	FamilyWealth        float64  `json:"family_wealth"`
+	IsRich              bool     `json:"is_rich"`
+	Country             string   `json:"country"`
+	CurrentCountry      string   `json:"current_country"`
 }
 
 type VisualizationEdge struct {
-	Source string `json:"source"`
-	Target string `json:"target"`
-	Type   string `json:"type"`
+	Source    string `json:"source"`
+	Target    string `json:"target"`
+	Type      string `json:"type"`
+	UnionType string `json:"union_type,omitempty"`
+	Relation  string `json:"relation,omitempty"`
+	Zygosity  string `json:"zygosity,omitempty"`
 }
 
 type VisualizationStats struct {
-	TotalPersons          int     `json:"total_persons"`
-	TotalFamilies         int     `json:"total_families"`
-	LivingPersons         int     `json:"living_persons"`
-	DeceasedPersons       int     `json:"deceased_persons"`
-	AverageAge            float64 `json:"average_age"`
-	OldestPerson          int     `json:"oldest_person_age"`
-	TotalChildren         int     `json:"total_children"`
-	AverageChildren       float64 `json:"average_children"`
-	DivorceCount          int     `json:"divorce_count"`
-	SingleCount           int     `json:"single_count"`
-	MarriedCount          int     `json:"married_count"`
-	MaleCount             int     `json:"male_count"`
-	FemaleCount           int     `json:"female_count"`
-	BirthsOutsideMarriage int     `json:"births_outside_marriage"`
-	TertiaryEducation     int     `json:"tertiary_education"`
-	EmployedCount         int     `json:"employed_count"`
-	AverageGDPPerCapita   float64 `json:"average_gdp_per_capita"`
-	AverageWealthIndex    float64 `json:"average_wealth_index"`
-	AverageFamilyWealth   float64 `json:"average_family_wealth"`
-	RichCount             int     `json:"rich_count"`
+	TotalPersons          int            `json:"total_persons"`
+	TotalFamilies         int            `json:"total_families"`
+	LivingPersons         int            `json:"living_persons"`
+	DeceasedPersons       int            `json:"deceased_persons"`
+	AverageAge            float64        `json:"average_age"`
+	OldestPerson          int            `json:"oldest_person_age"`
+	TotalChildren         int            `json:"total_children"`
+	AverageChildren       float64        `json:"average_children"`
+	DivorceCount          int            `json:"divorce_count"`
+	SingleCount           int            `json:"single_count"`
+	MarriedCount          int            `json:"married_count"`
+	RemarriedCount        int            `json:"remarried_count"`
+	WidowedCount          int            `json:"widowed_count"`
+	NeverMarriedCount     int            `json:"never_married_count"`
+	ChildlessCount        int            `json:"childless_count"`
+	MaleCount             int            `json:"male_count"`
+	FemaleCount           int            `json:"female_count"`
+	BirthsOutsideMarriage int            `json:"births_outside_marriage"`
+	SingleParentCount     int            `json:"single_parent_count"`
+	MarriageFamilies      int            `json:"marriage_families"`
+	CohabitationFamilies  int            `json:"cohabitation_families"`
+	SingleParentFamilies  int            `json:"single_parent_families"`
+	CivilPartnerships     int            `json:"civil_partnerships"`
+	DivorcedFamilies      int            `json:"divorced_families"`
+	AverageYearsToDivorce float64        `json:"average_years_to_divorce"`
+	SameSexUnions         int            `json:"same_sex_unions"`
+	AdoptedCount          int            `json:"adopted_count"`
+	MultipleBirthCount    int            `json:"multiple_birth_count"`
+	TertiaryEducation     int            `json:"tertiary_education"`
+	EmployedCount         int            `json:"employed_count"`
+	AverageGDPPerCapita   float64        `json:"average_gdp_per_capita"`
+	AverageWealthIndex    float64        `json:"average_wealth_index"`
+	AverageFamilyWealth   float64        `json:"average_family_wealth"`
+	RichCount             int            `json:"rich_count"`
+	AverageHeightMen      float64        `json:"average_height_men"`
+	AverageHeightWomen    float64        `json:"average_height_women"`
+	ProjectedPersons      int            `json:"projected_persons"`
+	ProjectedEvents       int            `json:"projected_events"`
+	Migration             MigrationStats `json:"migration"`
+}
+
+type MigrationStats struct {
+	MigrantCount          int            `json:"migrant_count"`
+	MigrationEvents       int            `json:"migration_events"`
+	MigrantShare          float64        `json:"migrant_share"`
+	AverageAgeAtMigration float64        `json:"average_age_at_migration"`
+	Destinations          map[string]int `json:"destinations"`
+	Origins               map[string]int `json:"origins"`
 }
 
 func WriteVisualizationJSON(tree *model.FamilyTree, filepath string) error {
@@ -139,55 +199,33 @@ func WriteVisualizationJSON(tree *model.FamilyTree, filepath string) error {
 	return nil
 }
 
-func TreeToVisualizationData(tree *model.FamilyTree) *VisualizationData {
-	persons := tree.GetAllPersons()
-	referenceYear := 0
-	maxGeneration := 0
-	for _, p := range persons {
-		if p.Generation > maxGeneration {
-			maxGeneration = p.Generation
-		}
-	}
-
-	var latestEvent time.Time
-	for _, p := range persons {
-		if p.Generation != maxGeneration {
-			continue
-		}
-		for _, ev := range p.Events {
-			if ev.Date.After(latestEvent) {
-				latestEvent = ev.Date
-			}
-		}
-		if p.BirthDate.After(latestEvent) {
-			latestEvent = p.BirthDate
-		}
-		if p.DeathDate != nil && p.DeathDate.After(latestEvent) {
-			latestEvent = *p.DeathDate
+func firstOfMultipleBirth(p *model.Person) bool {
+	for _, twinID := range p.TwinIDs {
+		if twinID < p.ID {
+			return false
 		}
 	}
+	return true
+}
 
-	if !latestEvent.IsZero() {
-		referenceYear = latestEvent.Year()
-	} else {
-		for _, p := range persons {
-			birthYear := p.BirthDate.Year()
-			if birthYear > referenceYear {
-				referenceYear = birthYear
-			}
-		}
-		if referenceYear == 0 {
-			referenceYear = time.Now().Year()
-		}
-	}
+func TreeToVisualizationData(tree *model.FamilyTree) *VisualizationData {
+	persons := tree.GetAllPersons()
+	referenceDate := tree.ReferenceDate()
 
 	data := &VisualizationData{
 		ID:            tree.ID,
 		RootID:        tree.RootPersonID,
 		Country:       tree.Country,
+		Region:        tree.Region,
 		Generations:   tree.Generations,
+		Ancestors:     tree.AncestorGenerations,
+		Descendants:   tree.DescendantGenerations,
 		Seed:          tree.Seed,
-		ReferenceYear: referenceYear,
+		ReferenceYear: referenceDate.Year(),
+		AsOf:          referenceDate.Format("2006-01-02"),
+		Scenario:      tree.Scenario,
+		ProjectUntil:  tree.ProjectUntil,
+		Profile:       tree.Profile,
 		Nodes:         make([]VisualizationNode, 0),
 		Edges:         make([]VisualizationEdge, 0),
 	}
@@ -201,8 +239,15 @@ func TreeToVisualizationData(tree *model.FamilyTree) *VisualizationData {
 	var wealthIndexCount int
 	var familyWealthTotal float64
 	var familyWealthCount int
+	var migrationAgeTotal float64
+	data.Stats.Migration.Destinations = make(map[string]int)
+	data.Stats.Migration.Origins = make(map[string]int)
+	heightTotal := make(map[model.Gender]float64)
+	heightCount := make(map[model.Gender]int)
 
 	for _, p := range persons {
+		maritalStatus := p.MaritalStatusAt(referenceDate)
+		alive := p.DeathDate == nil || p.DeathDate.After(referenceDate)
 		var deathYear *int
 		if p.DeathDate != nil {
 			year := p.DeathDate.Year()
@@ -213,13 +258,28 @@ func TreeToVisualizationData(tree *model.FamilyTree) *VisualizationData {
 			ID:                  p.ID,
 			Name:                p.FullName(),
 			FirstName:           p.FirstName,
+			GivenNames:          p.GivenNames,
+			CallName:            p.CallName,
+			Patronymic:          p.Patronymic,
 			LastName:            p.LastName,
+			BirthName:           p.BirthName,
+			MarriedName:         p.MarriedName,
+			Suffix:              p.Suffix,
+			NativeName:          p.NativeFullName(),
+			NativeFirstName:     p.NativeFirstName,
+			NativePatronymic:    p.NativePatronymic,
+			NativeLastName:      p.NativeLastName,
+			NameScript:          p.NameScript,
+			TwinIDs:             p.TwinIDs,
+			Zygosity:            string(p.Zygosity),
 			Gender:              string(p.Gender),
 			BirthYear:           p.BirthDate.Year(),
 			DeathYear:           deathYear,
-			IsAlive:             p.IsAlive(),
+			IsAlive:             alive,
+			Projected:           p.Projected,
+			DeathProjected:      p.DeathDate != nil && p.DeathDate.After(referenceDate),
 			Generation:          p.Generation,
-			MaritalStatus:       string(p.MaritalStatus),
+			MaritalStatus:       string(maritalStatus),
 			MarriageAge:         p.MarriageAge,
 			NumberOfChildren:    p.NumberOfChildren,
 			Education:           string(p.Education),
@@ -228,7 +288,9 @@ func TreeToVisualizationData(tree *model.FamilyTree) *VisualizationData {
 			TobaccoUse:          p.Health.TobaccoUse,
 			BornOutsideMarriage: p.BornOutsideMarriage,
 			IsSingleParent:      p.IsSingleParent,
+			Adopted:             p.Adopted,
 			Underweight:         p.Underweight,
+			Height:              p.Height,
 			Residence:           string(p.Residence),
 			GDPPerCapita:        p.GDPPerCapita,
 			WealthIndex:         p.WealthIndex,
@@ -237,9 +299,21 @@ func TreeToVisualizationData(tree *model.FamilyTree) *VisualizationData {
 			Country:             p.BirthCountry,
 			CurrentCountry:      p.CurrentCountry,
 		}
+		if p.NamesakeID != nil {
+			node.NamesakeID = *p.NamesakeID
+		}
 		data.Nodes = append(data.Nodes, node)
 
-		if p.IsAlive() {
+		if p.Projected {
+			data.Stats.ProjectedPersons++
+		}
+		for _, event := range p.Events {
+			if event.Projected {
+				data.Stats.ProjectedEvents++
+			}
+		}
+
+		if alive {
 			data.Stats.LivingPersons++
 		} else {
 			data.Stats.DeceasedPersons++
@@ -251,13 +325,18 @@ func TreeToVisualizationData(tree *model.FamilyTree) *VisualizationData {
 			data.Stats.FemaleCount++
 		}
 
-		switch p.MaritalStatus {
+		switch maritalStatus {
 		case model.Single:
 			data.Stats.SingleCount++
-		case model.Married, model.Remarried:
+		case model.Married:
+			data.Stats.MarriedCount++
+		case model.Remarried:
 			data.Stats.MarriedCount++
+			data.Stats.RemarriedCount++
 		case model.Divorced:
 			data.Stats.DivorceCount++
+		case model.Widowed:
+			data.Stats.WidowedCount++
 		}
 
 		if p.Education == model.Tertiary {
@@ -272,6 +351,18 @@ func TreeToVisualizationData(tree *model.FamilyTree) *VisualizationData {
 			data.Stats.BirthsOutsideMarriage++
 		}
 
+		if p.IsSingleParent {
+			data.Stats.SingleParentCount++
+		}
+
+		if p.Adopted {
+			data.Stats.AdoptedCount++
+		}
+
+		if len(p.TwinIDs) > 0 && firstOfMultipleBirth(p) {
+			data.Stats.MultipleBirthCount++
+		}
+
 		if p.GDPPerCapita > 0 {
 			gdpTotal += p.GDPPerCapita
 			gdpCount++
@@ -290,11 +381,40 @@ func TreeToVisualizationData(tree *model.FamilyTree) *VisualizationData {
 
 		age := p.AgeAtDeath()
 		if age < 0 {
-			age = referenceYear - p.BirthDate.Year()
+			age = p.Age(referenceDate)
 			if age < 0 {
 				age = 0
 			}
 		}
+
+		migrated := false
+		for _, event := range p.Events {
+			if event.Type != model.EventMigration {
+				continue
+			}
+			migrated = true
+			data.Stats.Migration.MigrationEvents++
+			data.Stats.Migration.Destinations[event.Location]++
+			data.Stats.Migration.Origins[event.Description]++
+			migrationAgeTotal += float64(p.Age(event.Date))
+		}
+		if migrated {
+			data.Stats.Migration.MigrantCount++
+		}
+
+		if age >= adultAge && p.Height > 0 {
+			heightTotal[p.Gender] += p.Height
+			heightCount[p.Gender]++
+		}
+
+		if age >= completedFamilyAge {
+			if maritalStatus == model.Single {
+				data.Stats.NeverMarriedCount++
+			}
+			if len(p.ChildrenIDs) == 0 {
+				data.Stats.ChildlessCount++
+			}
+		}
 		totalAge += float64(age)
 		ageCount++
 		if age > oldestAge {
@@ -302,37 +422,91 @@ func TreeToVisualizationData(tree *model.FamilyTree) *VisualizationData {
 		}
 	}
 
+	parentEdges := make(map[string]bool)
+	addParentEdge := func(parentID, childID string) {
+		key := parentID + "-" + childID
+		if parentEdges[key] || tree.GetPerson(parentID) == nil {
+			return
+		}
+		parentEdges[key] = true
+		edge := VisualizationEdge{
+			Source: parentID,
+			Target: childID,
+			Type:   "parent",
+		}
+		if child := tree.GetPerson(childID); child != nil {
+			edge.Relation = string(parentKind(child, parentID))
+		}
+		data.Edges = append(data.Edges, edge)
+	}
+
 	for _, p := range persons {
 		if p.FatherID != nil {
+			addParentEdge(*p.FatherID, p.ID)
+		}
+		if p.MotherID != nil {
+			addParentEdge(*p.MotherID, p.ID)
+		}
+	}
+
+	for _, p := range persons {
+		for _, link := range p.Parents {
+			if parentEdges[link.ParentID+"-"+p.ID] || tree.GetPerson(link.ParentID) == nil {
+				continue
+			}
+			parentEdges[link.ParentID+"-"+p.ID] = true
 			data.Edges = append(data.Edges, VisualizationEdge{
-				Source: *p.FatherID,
-				Target: p.ID,
-				Type:   "parent",
+				Source:   link.ParentID,
+				Target:   p.ID,
+				Type:     "guardian",
+				Relation: string(link.Kind),
 			})
 		}
-		if p.MotherID != nil {
+	}
+
+	for _, p := range persons {
+		for _, twinID := range p.TwinIDs {
+			twin := tree.GetPerson(twinID)
+			if twin == nil || twinID < p.ID {
+				continue
+			}
+			zygosity := model.Fraternal
+			if p.Zygosity == model.Identical && twin.Zygosity == model.Identical {
+				zygosity = model.Identical
+			}
 			data.Edges = append(data.Edges, VisualizationEdge{
-				Source: *p.MotherID,
-				Target: p.ID,
-				Type:   "parent",
+				Source:   p.ID,
+				Target:   twinID,
+				Type:     "twin",
+				Zygosity: string(zygosity),
 			})
 		}
 	}
 
 	seen := make(map[string]bool)
-	for _, p := range persons {
-		for _, spouseID := range p.SpouseIDs {
-			key := p.ID + "-" + spouseID
-			reverseKey := spouseID + "-" + p.ID
-			if !seen[key] && !seen[reverseKey] {
-				data.Edges = append(data.Edges, VisualizationEdge{
-					Source: p.ID,
-					Target: spouseID,
-					Type:   "spouse",
-				})
-				seen[key] = true
+	for _, f := range tree.GetAllFamilies() {
+		if !f.IsSingleParent() {
+			for _, partnerID := range f.PartnerIDs {
+				for _, childID := range f.ChildrenIDs {
+					addParentEdge(partnerID, childID)
+				}
 			}
 		}
+
+		if !f.IsCouple() || f.IsSingleParent() {
+			continue
+		}
+		key := f.PartnerIDs[0] + "-" + f.PartnerIDs[1]
+		if seen[key] {
+			continue
+		}
+		data.Edges = append(data.Edges, VisualizationEdge{
+			Source:    f.PartnerIDs[0],
+			Target:    f.PartnerIDs[1],
+			Type:      "spouse",
+			UnionType: string(f.UnionType),
+		})
+		seen[key] = true
 	}
 
 	data.Stats.TotalPersons = tree.PersonCount()
@@ -350,13 +524,53 @@ func TreeToVisualizationData(tree *model.FamilyTree) *VisualizationData {
 	if familyWealthCount > 0 {
 		data.Stats.AverageFamilyWealth = familyWealthTotal / float64(familyWealthCount)
 	}
+	if data.Stats.Migration.MigrantCount > 0 {
+		data.Stats.Migration.MigrantShare = float64(data.Stats.Migration.MigrantCount) / float64(len(persons))
+		data.Stats.Migration.AverageAgeAtMigration = migrationAgeTotal / float64(data.Stats.Migration.MigrationEvents)
+	}
+	if heightCount[model.Male] > 0 {
+		data.Stats.AverageHeightMen = heightTotal[model.Male] / float64(heightCount[model.Male])
+	}
+	if heightCount[model.Female] > 0 {
+		data.Stats.AverageHeightWomen = heightTotal[model.Female] / float64(heightCount[model.Female])
+	}
 
+	var yearsToDivorceTotal float64
 	for _, f := range tree.GetAllFamilies() {
 		data.Stats.TotalChildren += f.ChildCount()
+		if f.IsDivorced() {
+			data.Stats.DivorcedFamilies++
+			yearsToDivorceTotal += f.YearsToDivorce()
+		}
+		switch f.UnionType {
+		case model.UnionMarriage:
+			data.Stats.MarriageFamilies++
+		case model.UnionCohabitation:
+			data.Stats.CohabitationFamilies++
+		case model.UnionCivilPartnership:
+			data.Stats.CivilPartnerships++
+		case model.UnionNone:
+			data.Stats.SingleParentFamilies++
+		}
+		if f.SameSex {
+			data.Stats.SameSexUnions++
+		}
 	}
 	if tree.FamilyCount() > 0 {
 		data.Stats.AverageChildren = float64(data.Stats.TotalChildren) / float64(tree.FamilyCount())
 	}
+	if data.Stats.DivorcedFamilies > 0 {
+		data.Stats.AverageYearsToDivorce = yearsToDivorceTotal / float64(data.Stats.DivorcedFamilies)
+	}
 
 	return data
 }
+
+func parentKind(child *model.Person, parentID string) model.ParentKind {
+	for _, link := range child.Parents {
+		if link.ParentID == parentID {
+			return link.Kind
+		}
+	}
+	return model.BiologicalParent
+}
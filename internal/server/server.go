@@ -15,11 +15,17 @@ import (
 	"github.com/familytree-generator/internal/output"
 )
 
+const (
+	maxAPIPersons   = 1000
+	maxAPIAncestors = 10
+)
+
 type Server struct {
 	repo        *data.Repository
 	addr        string
 	webDir      string
 	rateLimiter *RateLimiter
+	trees       *TreeStore
 }
 
 func NewServer(repo *data.Repository, addr string, webDir string) *Server {
@@ -28,6 +34,7 @@ func NewServer(repo *data.Repository, addr string, webDir string) *Server {
 		addr:        addr,
 		webDir:      webDir,
 		rateLimiter: NewRateLimiter(10, time.Minute),
+		trees:       NewTreeStore(50),
 	}
 }
 
@@ -37,6 +44,7 @@ func (s *Server) Start() error {
 	mux.HandleFunc("/api/generate", s.corsMiddleware(s.handleGenerate))
 	mux.HandleFunc("/api/countries", s.corsMiddleware(s.handleCountries))
 	mux.HandleFunc("/api/country/", s.corsMiddleware(s.handleCountryStats))
+	mux.HandleFunc("/api/tree/", s.corsMiddleware(s.handleTreeSnapshot))
 	mux.HandleFunc("/api/health", s.corsMiddleware(s.handleHealth))
 
 	if s.webDir != "" {
@@ -49,7 +57,9 @@ func (s *Server) Start() error {
 	log.Printf("  GET  /api/health - Health check")
 	log.Printf("  GET  /api/countries - List available countries")
 	log.Printf("  GET  /api/country/{slug} - Get country statistics")
+	log.Printf("  GET  /api/country/{slug}/regions - List name regions for a country")
 	log.Printf("  POST /api/generate - Generate a family tree")
+	log.Printf("  GET  /api/tree/{id}/snapshot?date= - State of a generated tree at a date")
 
 	return http.ListenAndServe(s.addr, mux)
 }
@@ -97,18 +107,29 @@ func isAllowedOrigin(origin string) bool {
 }
 
 type GenerateRequest struct {
-	Country            string `json:"country"`
-	Generations        int    `json:"generations"`
-	Seed               int64  `json:"seed"`
-	StartYear          int    `json:"start_year"`
-	Gender             string `json:"gender"`
-	IncludeExtended    bool   `json:"include_extended"`
-	LifeExpectancyMode string `json:"life_expectancy_mode"`
+	Country               string   `json:"country"`
+	AncestorGenerations   *int     `json:"ancestor_generations"`
+	DescendantGenerations *int     `json:"descendant_generations"`
+	Generations           *int     `json:"generations"`
+	Seed                  int64    `json:"seed"`
+	StartYear             int      `json:"start_year"`
+	Gender                string   `json:"gender"`
+	Region                string   `json:"region"`
+	HostNameShare         *float64 `json:"host_name_share"`
+	IncludeExtended       bool     `json:"include_extended"`
+	CollateralDepth       int      `json:"collateral_depth"`
+	MaxPersons            int      `json:"max_persons"`
+	LifeExpectancyMode    string   `json:"life_expectancy_mode"`
+	AsOf                  string   `json:"as_of"`
+	Scenario              string   `json:"scenario"`
+	ProjectUntil          int      `json:"project_until"`
+	Profile               string   `json:"profile"`
 }
 
 type GenerateResponse struct {
 	Success bool                      `json:"success"`
 	Message string                    `json:"message,omitempty"`
+	TreeID  string                    `json:"tree_id,omitempty"`
 	Tree    *output.VisualizationData `json:"tree,omitempty"`
 	Stats   *TreeStats                `json:"stats,omitempty"`
 }
@@ -144,11 +165,48 @@ func (s *Server) handleGenerate(w http.ResponseWriter, r *http.Request) {
 	if req.Country == "" {
 		req.Country = "germany"
 	}
-	if req.Generations < 1 {
-		req.Generations = 3
+	var message string
+	if req.Generations != nil {
+		message = "generations is deprecated and will be removed; use ancestor_generations and descendant_generations"
+		if legacy := *req.Generations - 1; legacy >= 0 {
+			if req.AncestorGenerations == nil {
+				req.AncestorGenerations = &legacy
+			}
+			if req.DescendantGenerations == nil {
+				req.DescendantGenerations = &legacy
+			}
+		}
+	}
+	profile := data.ParseProfile(req.Profile)
+	_, minStartYear := generator.ProfileLimits(profile)
+	ancestorGenerations := 2
+	if req.AncestorGenerations != nil {
+		ancestorGenerations = *req.AncestorGenerations
+	}
+	if ancestorGenerations < 0 {
+		ancestorGenerations = 0
+	}
+	if ancestorGenerations > maxAPIAncestors {
+		ancestorGenerations = maxAPIAncestors
+	}
+	descendantGenerations := 2
+	if req.DescendantGenerations != nil {
+		descendantGenerations = *req.DescendantGenerations
+	}
+	if descendantGenerations < 0 {
+		descendantGenerations = 0
+	}
+	if descendantGenerations > 8 {
+		descendantGenerations = 8
+	}
+	if req.CollateralDepth < 0 {
+		req.CollateralDepth = 0
+	}
+	if req.CollateralDepth > generator.MaxCollateralDepth {
+		req.CollateralDepth = generator.MaxCollateralDepth
 	}
-	if req.Generations > 10 {
-		req.Generations = 10
+	if req.MaxPersons <= 0 || req.MaxPersons > maxAPIPersons {
+		req.MaxPersons = maxAPIPersons
 	}
 	if req.Seed == 0 {
 		req.Seed = time.Now().UnixNano()
@@ -156,14 +214,40 @@ func (s *Server) handleGenerate(w http.ResponseWriter, r *http.Request) {
 	if req.StartYear == 0 {
 		req.StartYear = 1970
 	}
+	if req.StartYear < minStartYear {
+		req.StartYear = minStartYear
+	}
+	if req.StartYear > 2150 {
+		req.StartYear = 2150
+	}
+	if req.ProjectUntil < 0 {
+		req.ProjectUntil = 0
+	}
+	if req.ProjectUntil > 2150 {
+		req.ProjectUntil = 2150
+	}
+	hostNameShare := 0.5
+	if req.HostNameShare != nil {
+		hostNameShare = *req.HostNameShare
+	}
 	if req.LifeExpectancyMode == "" {
-		req.LifeExpectancyMode = string(generator.LifeExpectancyTotal)
+		req.LifeExpectancyMode = string(generator.LifeExpectancyByGender)
+	}
+
+	asOf, err := generator.ParseAsOf(req.AsOf)
+	if err != nil {
+		s.jsonError(w, "Invalid as_of: "+err.Error(), http.StatusBadRequest)
+		return
 	}
 
 	if err := s.repo.ValidateCountry(req.Country); err != nil {
 		s.jsonError(w, "Invalid country: "+err.Error(), http.StatusBadRequest)
 		return
 	}
+	if err := s.repo.ValidateRegion(req.Country, req.Region); err != nil {
+		s.jsonError(w, "Invalid region: "+err.Error(), http.StatusBadRequest)
+		return
+	}
 
 	var gender model.Gender
 	switch strings.ToUpper(req.Gender) {
@@ -174,13 +258,22 @@ func (s *Server) handleGenerate(w http.ResponseWriter, r *http.Request) {
 	}
 
 	config := generator.Config{
-		Country:            req.Country,
-		Generations:        req.Generations,
-		Seed:               req.Seed,
-		StartYear:          req.StartYear,
-		RootGender:         gender,
-		IncludeExtended:    req.IncludeExtended,
-		LifeExpectancyMode: generator.ParseLifeExpectancyMode(req.LifeExpectancyMode),
+		Country:               req.Country,
+		AncestorGenerations:   ancestorGenerations,
+		DescendantGenerations: descendantGenerations,
+		Seed:                  req.Seed,
+		StartYear:             req.StartYear,
+		RootGender:            gender,
+		Region:                req.Region,
+		HostNameShare:         hostNameShare,
+		IncludeExtended:       req.IncludeExtended,
+		CollateralDepth:       req.CollateralDepth,
+		MaxPersons:            req.MaxPersons,
+		LifeExpectancyMode:    generator.ParseLifeExpectancyMode(req.LifeExpectancyMode),
+		AsOf:                  asOf,
+		Scenario:              data.ParseScenario(req.Scenario),
+		ProjectUntil:          req.ProjectUntil,
+		Profile:               profile,
 	}
 
 	startTime := time.Now()
@@ -191,6 +284,7 @@ func (s *Server) handleGenerate(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 	generationTime := time.Since(startTime)
+	treeID := s.trees.Put(tree)
 
 	vizData := output.TreeToVisualizationData(tree)
 
@@ -214,6 +308,8 @@ func (s *Server) handleGenerate(w http.ResponseWriter, r *http.Request) {
 
 	response := GenerateResponse{
 		Success: true,
+		Message: message,
+		TreeID:  treeID,
 		Tree:    vizData,
 		Stats:   stats,
 	}
@@ -221,13 +317,49 @@ func (s *Server) handleGenerate(w http.ResponseWriter, r *http.Request) {
 	s.jsonResponse(w, response)
 }
 
+func (s *Server) handleTreeSnapshot(w http.ResponseWriter, r *http.Request) {
+	if r.Method != "GET" {
+		s.jsonError(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	id, ok := strings.CutSuffix(strings.TrimPrefix(r.URL.Path, "/api/tree/"), "/snapshot")
+	if !ok || id == "" {
+		s.jsonError(w, "Not found", http.StatusNotFound)
+		return
+	}
+
+	tree := s.trees.Get(id)
+	if tree == nil {
+		s.jsonError(w, "Tree not found: "+id, http.StatusNotFound)
+		return
+	}
+
+	date := tree.ReferenceDate()
+	if value := r.URL.Query().Get("date"); value != "" {
+		parsed, err := generator.ParseAsOf(value)
+		if err != nil {
+			s.jsonError(w, "Invalid date: "+err.Error(), http.StatusBadRequest)
+			return
+		}
+		date = parsed
+	}
+	if horizon := tree.ObservedUntil(); date.After(horizon) {
+		s.jsonError(w, fmt.Sprintf("Date %s is after %s, the last date this tree was generated for", date.Format("2006-01-02"), horizon.Format("2006-01-02")), http.StatusBadRequest)
+		return
+	}
+
+	s.jsonResponse(w, tree.SnapshotAt(date))
+}
+
 type CountryInfo struct {
-	Slug           string  `json:"slug"`
-	Name           string  `json:"name"`
-	ISOCode        string  `json:"iso_code"`
-	HasNameData    bool    `json:"has_name_data"`
-	Population     float64 `json:"population,omitempty"`
-	LifeExpectancy float64 `json:"life_expectancy,omitempty"`
+	Slug           string   `json:"slug"`
+	Name           string   `json:"name"`
+	ISOCode        string   `json:"iso_code"`
+	HasNameData    bool     `json:"has_name_data"`
+	Regions        []string `json:"regions,omitempty"`
+	Population     float64  `json:"population,omitempty"`
+	LifeExpectancy float64  `json:"life_expectancy,omitempty"`
 }
 
 func (s *Server) handleCountries(w http.ResponseWriter, r *http.Request) {
@@ -246,6 +378,7 @@ func (s *Server) handleCountries(w http.ResponseWriter, r *http.Request) {
 			Name:           stats.Name,
 			ISOCode:        stats.ISOCode,
 			HasNameData:    true,
+			Regions:        s.repo.GetNameRegions(slug),
 			Population:     stats.Population,
 			LifeExpectancy: stats.LifeExpectancy,
 		}
@@ -265,6 +398,7 @@ func (s *Server) handleCountryStats(w http.ResponseWriter, r *http.Request) {
 	}
 
 	slug := strings.TrimPrefix(r.URL.Path, "/api/country/")
+	slug, wantRegions := strings.CutSuffix(slug, "/regions")
 	if slug == "" {
 		s.jsonError(w, "Country slug required", http.StatusBadRequest)
 		return
@@ -275,6 +409,16 @@ func (s *Server) handleCountryStats(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	if wantRegions {
+		regions := s.repo.GetNameRegions(slug)
+		s.jsonResponse(w, map[string]interface{}{
+			"slug":    slug,
+			"regions": regions,
+			"count":   len(regions),
+		})
+		return
+	}
+
 	stats := s.repo.GetCountryStats(slug)
 
 	currentYear := time.Now().Year()
@@ -0,0 +1,50 @@
+package server
+
+import (
+	"crypto/rand"
+	"sync"
+
+	"github.com/familytree-generator/internal/model"
+)
+
+type TreeStore struct {
+	mu    sync.Mutex
+	trees map[string]*model.FamilyTree
+	order []string
+	limit int
+}
+
+func NewTreeStore(limit int) *TreeStore {
+	return &TreeStore{
+		trees: make(map[string]*model.FamilyTree),
+		limit: limit,
+	}
+}
+
+func (ts *TreeStore) Put(tree *model.FamilyTree) string {
+	ts.mu.Lock()
+	defer ts.mu.Unlock()
+
+	id := "tree_" + rand.Text()
+	for ts.trees[id] != nil {
+		id = "tree_" + rand.Text()
+	}
+	tree.ID = id
+
+	ts.order = append(ts.order, id)
+	ts.trees[id] = tree
+
+	for len(ts.order) > ts.limit {
+		delete(ts.trees, ts.order[0])
+		ts.order = ts.order[1:]
+	}
+
+	return id
+}
+
+func (ts *TreeStore) Get(id string) *model.FamilyTree {
+	ts.mu.Lock()
+	defer ts.mu.Unlock()
+
+	return ts.trees[id]
+}
@@ -97,12 +97,19 @@ export const GeneratorForm: React.FC<GeneratorFormProps> = ({ onGenerate, onErro
   const [apiOnline, setApiOnline] = useState<boolean | null>(null);
 
   const [country, setCountry] = useState('germany');
-  const [generations, setGenerations] = useState(3);
+  const [ancestorGenerations, setAncestorGenerations] = useState(2);
+  const [descendantGenerations, setDescendantGenerations] = useState(2);
   const [seed, setSeed] = useState('');
   const [startYear, setStartYear] = useState(1970);
+  const [asOf, setAsOf] = useState('');
+  const [projectUntil, setProjectUntil] = useState(0);
+  const [scenario, setScenario] = useState<'low' | 'medium' | 'high'>('medium');
+  const [profile, setProfile] = useState<'modern' | 'early-modern'>('modern');
   const [gender, setGender] = useState('');
+  const [region, setRegion] = useState('');
   const [extended, setExtended] = useState(false);
-  const [lifeExpectancyMode, setLifeExpectancyMode] = useState<'total' | 'female' | 'male' | 'by_gender'>('total');
+  const [collateralDepth, setCollateralDepth] = useState(0);
+  const [lifeExpectancyMode, setLifeExpectancyMode] = useState<'total' | 'female' | 'male' | 'by_gender'>('by_gender');
 
   useEffect(() => {
     
@@ -130,10 +137,13 @@ export const GeneratorForm: React.FC<GeneratorFormProps> = ({ onGenerate, onErro
     try {
       const request: GenerateRequest = {
         country,
-        generations,
+        ancestor_generations: ancestorGenerations,
+        descendant_generations: descendantGenerations,
         start_year: startYear,
         include_extended: extended,
+        collateral_depth: extended ? collateralDepth : 0,
         life_expectancy_mode: lifeExpectancyMode,
+        profile,
       };
 
       if (seed) {
@@ -144,6 +154,19 @@ export const GeneratorForm: React.FC<GeneratorFormProps> = ({ onGenerate, onErro
         request.gender = gender as 'M' | 'F';
       }
 
+      if (region) {
+        request.region = region;
+      }
+
+      if (asOf) {
+        request.as_of = asOf;
+      }
+
+      if (projectUntil > 0) {
+        request.project_until = projectUntil;
+        request.scenario = scenario;
+      }
+
       const response = await generateTree(request);
 
       if (response.success && response.tree) {
@@ -158,6 +181,8 @@ export const GeneratorForm: React.FC<GeneratorFormProps> = ({ onGenerate, onErro
     }
   };
 
+  const regions = countries.find(c => c.slug === country)?.regions ?? [];
+
   if (apiOnline === false) {
     return (
       <div style={styles.form}>
@@ -190,7 +215,10 @@ export const GeneratorForm: React.FC<GeneratorFormProps> = ({ onGenerate, onErro
           <select
             style={styles.select}
             value={country}
-            onChange={e => setCountry(e.target.value)}
+            onChange={e => {
+              setCountry(e.target.value);
+              setRegion('');
+            }}
           >
             {countries.map(c => (
               <option key={c.slug} value={c.slug}>
@@ -200,15 +228,43 @@ export const GeneratorForm: React.FC<GeneratorFormProps> = ({ onGenerate, onErro
           </select>
         </div>
 
+        {regions.length > 0 && (
+          <div style={styles.field}>
+            <label style={styles.label}>Name Region</label>
+            <select
+              style={styles.select}
+              value={region}
+              onChange={e => setRegion(e.target.value)}
+            >
+              <option value="">Any</option>
+              {regions.map(r => (
+                <option key={r} value={r}>{r}</option>
+              ))}
+            </select>
+          </div>
+        )}
+
         <div style={styles.field}>
-          <label style={styles.label}>Generations</label>
+          <label style={styles.label}>Ancestor Generations</label>
           <input
             type="number"
             style={styles.input}
-            min={1}
+            min={0}
             max={10}
-            value={generations}
-            onChange={e => setGenerations(parseInt(e.target.value, 10))}
+            value={ancestorGenerations}
+            onChange={e => setAncestorGenerations(parseInt(e.target.value, 10))}
+          />
+        </div>
+
+        <div style={styles.field}>
+          <label style={styles.label}>Descendant Generations</label>
+          <input
+            type="number"
+            style={styles.input}
+            min={0}
+            max={8}
+            value={descendantGenerations}
+            onChange={e => setDescendantGenerations(parseInt(e.target.value, 10))}
           />
         </div>
 
@@ -217,12 +273,23 @@ export const GeneratorForm: React.FC<GeneratorFormProps> = ({ onGenerate, onErro
           <input
             type="number"
             style={styles.input}
-            min={1800}
-            max={2024}
+            min={profile === 'early-modern' ? 1500 : 1800}
+            max={2150}
             value={startYear}
             onChange={e => setStartYear(parseInt(e.target.value, 10))}
           />
         </div>
+
+        <div style={styles.field}>
+          <label style={styles.label}>As Of (optional)</label>
+          <input
+            type="date"
+            style={styles.input}
+            value={asOf}
+            onChange={e => setAsOf(e.target.value)}
+          />
+          <div style={styles.hint}>Date the tree is observed at; defaults to today</div>
+        </div>
       </div>
 
       <div style={styles.row}>
@@ -263,6 +330,22 @@ export const GeneratorForm: React.FC<GeneratorFormProps> = ({ onGenerate, onErro
           </label>
         </div>
 
+        <div style={styles.field}>
+          <label style={styles.label}>Collateral Lines</label>
+          <select
+            style={styles.select}
+            value={collateralDepth}
+            onChange={e => setCollateralDepth(parseInt(e.target.value, 10))}
+            disabled={!extended}
+          >
+            <option value={0}>Siblings only</option>
+            <option value={1}>First cousins, nieces &amp; nephews</option>
+            <option value={2}>Up to second cousins</option>
+            <option value={3}>Up to third cousins</option>
+            <option value={4}>Up to fourth cousins</option>
+          </select>
+        </div>
+
         <div style={styles.field}>
           <label style={styles.label}>Life Expectancy</label>
           <select
@@ -275,7 +358,51 @@ export const GeneratorForm: React.FC<GeneratorFormProps> = ({ onGenerate, onErro
             <option value="male">Male</option>
             <option value="by_gender">By gender</option>
           </select>
-          <div style={styles.hint}>Controls which life expectancy baseline to use</div>
+          <div style={styles.hint}>Each sex uses its own life expectancy unless overridden</div>
+        </div>
+      </div>
+
+      <div style={styles.row}>
+        <div style={styles.field}>
+          <label style={styles.label}>Project Until</label>
+          <select
+            style={styles.select}
+            value={projectUntil}
+            onChange={e => setProjectUntil(parseInt(e.target.value, 10))}
+          >
+            <option value={0}>No projection</option>
+            <option value={2050}>2050</option>
+            <option value={2100}>2100</option>
+            <option value={2150}>2150</option>
+          </select>
+          <div style={styles.hint}>Events after the as-of date are marked as projected</div>
+        </div>
+
+        <div style={styles.field}>
+          <label style={styles.label}>Scenario</label>
+          <select
+            style={styles.select}
+            value={scenario}
+            onChange={e => setScenario(e.target.value as 'low' | 'medium' | 'high')}
+            disabled={projectUntil === 0}
+          >
+            <option value="low">Low (fertility 1.25, slow mortality decline)</option>
+            <option value="medium">Medium (fertility 1.75)</option>
+            <option value="high">High (fertility 2.25, fast mortality decline)</option>
+          </select>
+        </div>
+
+        <div style={styles.field}>
+          <label style={styles.label}>Demographic Profile</label>
+          <select
+            style={styles.select}
+            value={profile}
+            onChange={e => setProfile(e.target.value as 'modern' | 'early-modern')}
+          >
+            <option value="modern">Modern (observed data)</option>
+            <option value="early-modern">Early modern (1500–1900)</option>
+          </select>
+          <div style={styles.hint}>Late marriage, high child mortality and parish names before 1900</div>
         </div>
       </div>
 
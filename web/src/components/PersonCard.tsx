@@ -34,6 +34,10 @@ const styles: Record<string, React.CSSProperties> = {
   deceased: {
     opacity: 0.8,
   },
+  projected: {
+    borderStyle: 'dashed',
+    borderColor: 'rgba(255,255,255,0.8)',
+  },
   root: {
     border: '2px solid gold',
   },
@@ -62,9 +66,10 @@ export const PersonCard: React.FC<PersonCardProps> = ({ person, isSelected, isRo
         ...(isRoot ? styles.root : {}),
         ...(isSelected ? styles.selected : {}),
         ...(!person.is_alive ? styles.deceased : {}),
+        ...(person.projected ? styles.projected : {}),
       }}
       onClick={onClick}
-      title={`${person.name}\nAge: ${age}\n${person.is_alive ? 'Living' : 'Deceased'}`}
+      title={`${person.name}\nAge: ${age}\n${person.is_alive ? 'Living' : 'Deceased'}${person.projected ? '\nProjected' : ''}`}
     >
       <div style={styles.name}>{person.name}</div>
       <div style={styles.years}>{years}</div>
@@ -189,6 +189,11 @@ export const PersonDetail: React.FC<PersonDetailProps> = ({ person, referenceYea
           </div>
           <div>
             <div style={styles.name}>{person.name}</div>
+            {person.native_name && (
+              <div style={styles.status} lang={person.name_script ? `und-${person.name_script}` : undefined}>
+                {person.native_name}
+              </div>
+            )}
             <div style={styles.status}>
               <span style={{
                 ...styles.badge,
@@ -207,6 +212,15 @@ export const PersonDetail: React.FC<PersonDetailProps> = ({ person, referenceYea
         { }
         <div style={styles.section}>
           <div style={styles.sectionTitle}>Basic Information</div>
+          {person.given_names && person.given_names.length > 1 && (
+            <div style={styles.row}>
+              <span style={styles.label}>Given Names:</span>
+              <span style={styles.value}>
+                {person.given_names.join(' ')}
+                {person.call_name && person.call_name !== person.first_name && ` (called ${person.call_name})`}
+              </span>
+            </div>
+          )}
           <div style={styles.row}>
             <span style={styles.label}>Birth Year:</span>
             <span style={styles.value}>{person.birth_year}</span>
@@ -308,6 +322,25 @@ export const PersonDetail: React.FC<PersonDetailProps> = ({ person, referenceYea
             <span style={styles.value}>{person.number_of_children}</span>
           </div>
 
+          {(person.projected || person.death_projected) && (
+            <div style={styles.row}>
+              <span style={styles.label}>Projection:</span>
+              <span style={styles.value}>
+                {person.projected ? 'Projected birth' : 'Projected death'}
+              </span>
+            </div>
+          )}
+
+          {person.twin_ids && person.twin_ids.length > 0 && (
+            <div style={styles.row}>
+              <span style={styles.label}>Multiple Birth:</span>
+              <span style={styles.value}>
+                {person.twin_ids.length === 1 ? 'Twin' : 'Triplet'}
+                {person.zygosity && ` (${person.zygosity})`}
+              </span>
+            </div>
+          )}
+
           {person.born_outside_marriage && (
             <div style={styles.row}>
               <span style={styles.label}>Born Outside Marriage:</span>
@@ -184,6 +184,25 @@ export const StatsPanel: React.FC<StatsPanelProps> = ({ stats, country, seed, re
           <span style={styles.value}>{stats.divorce_count}</span>
         </div>
 
+        {(stats.divorced_families ?? 0) > 0 && (
+          <div style={styles.stat}>
+            <span style={styles.label}>Avg. Years to Divorce:</span>
+            <span style={styles.value}>{stats.average_years_to_divorce?.toFixed(1)}</span>
+          </div>
+        )}
+
+        <div style={styles.stat}>
+          <span style={styles.label}>Widowed:</span>
+          <span style={styles.value}>{stats.widowed_count ?? 0}</span>
+        </div>
+
+        {(stats.projected_events ?? 0) > 0 && (
+          <div style={styles.stat}>
+            <span style={styles.label}>Projected Events:</span>
+            <span style={styles.value}>{stats.projected_events} ({stats.projected_persons ?? 0} persons)</span>
+          </div>
+        )}
+
         <div style={styles.stat}>
           <span style={styles.label}>Born Outside Marriage:</span>
           <span style={styles.value}>{stats.births_outside_marriage}</span>
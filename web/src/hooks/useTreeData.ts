@@ -19,6 +19,8 @@ const sampleData: VisualizationData = {
   root_id: "P001",
   country: "united-states",
   generations: 3,
+  ancestor_generations: 1,
+  descendant_generations: 1,
   seed: 12345,
   reference_year: 2018,
   nodes: [
@@ -4,23 +4,67 @@ export interface VisualizationData {
   id: string;
   root_id: string;
   country: string;
+  region?: string;
   generations: number;
+  ancestor_generations?: number;
+  descendant_generations?: number;
   seed: number;
   reference_year?: number;
+  as_of?: string;
+  scenario?: 'low' | 'medium' | 'high';
+  project_until?: number;
+  profile?: DemographicProfile;
   nodes: VisualizationNode[];
   edges: VisualizationEdge[];
   stats: VisualizationStats;
 }
+
+export interface DemographicProfile {
+  name: string;
+  from_year: number;
+  until_year: number;
+  blend_from: number;
+  countries: CountryAssumptions[];
+  assumptions: string[];
+}
+
+export interface CountryAssumptions {
+  country: string;
+  marriage_pattern: string;
+  women_marriage_age: number;
+  marriage_rate: number;
+  total_fertility: number;
+  youth_mortality: number;
+  births_outside_marriage: number;
+  single_parent_share: number;
+  name_group?: string;
+}
 
 export interface VisualizationNode {
   id: string;
   name: string;
   first_name: string;
+  given_names?: string[];
+  call_name?: string;
+  patronymic?: string;
   last_name: string;
+  birth_name?: string;
+  married_name?: string;
+  suffix?: string;
+  namesake_id?: string;
+  twin_ids?: string[];
+  zygosity?: 'identical' | 'fraternal';
+  native_name?: string;
+  native_first_name?: string;
+  native_patronymic?: string;
+  native_last_name?: string;
+  name_script?: string;
   gender: 'M' | 'F';
   birth_year: number;
   death_year?: number;
   is_alive: boolean;
+  projected?: boolean;
+  death_projected?: boolean;
   generation: number;
   marital_status: string;
   marriage_age?: number;
@@ -31,7 +75,9 @@ export interface VisualizationNode {
   tobacco_use: boolean;
   born_outside_marriage: boolean;
   is_single_parent: boolean;
+  adopted?: boolean;
   underweight?: boolean;
+  height_cm?: number;
   residence?: 'urban' | 'rural';
   gdp_per_capita?: number;
   wealth_index?: number;
@@ -44,7 +90,19 @@ export interface VisualizationNode {
 export interface VisualizationEdge {
   source: string;
   target: string;
-  type: 'parent' | 'spouse';
+  type: 'parent' | 'spouse' | 'twin' | 'guardian';
+  union_type?: 'marriage' | 'civil_partnership' | 'cohabitation' | 'none';
+  relation?: 'biological' | 'adoptive' | 'step' | 'foster';
+  zygosity?: 'identical' | 'fraternal';
+}
+
+export interface MigrationStats {
+  migrant_count: number;
+  migration_events: number;
+  migrant_share: number;
+  average_age_at_migration: number;
+  destinations: Record<string, number>;
+  origins: Record<string, number>;
 }
 
 export interface VisualizationStats {
@@ -59,15 +117,34 @@ export interface VisualizationStats {
   divorce_count: number;
   single_count: number;
   married_count: number;
+  remarried_count?: number;
+  widowed_count?: number;
+  never_married_count?: number;
+  childless_count?: number;
   male_count: number;
   female_count: number;
   births_outside_marriage: number;
+  single_parent_count?: number;
+  marriage_families?: number;
+  cohabitation_families?: number;
+  single_parent_families?: number;
+  civil_partnerships?: number;
+  divorced_families?: number;
+  average_years_to_divorce?: number;
+  same_sex_unions?: number;
+  adopted_count?: number;
+  multiple_birth_count?: number;
+  projected_persons?: number;
+  projected_events?: number;
   tertiary_education: number;
   employed_count: number;
   average_gdp_per_capita: number;
   average_wealth_index: number;
   average_family_wealth: number;
   rich_count: number;
+  average_height_men?: number;
+  average_height_women?: number;
+  migration?: MigrationStats;
 }
 
 
@@ -87,17 +164,27 @@ export interface HierarchyNode {
 
 export interface GenerateRequest {
   country: string;
-  generations: number;
+  ancestor_generations: number;
+  descendant_generations: number;
   seed?: number;
   start_year?: number;
   gender?: 'M' | 'F';
+  region?: string;
+  host_name_share?: number;
   include_extended?: boolean;
+  collateral_depth?: number;
+  max_persons?: number;
   life_expectancy_mode?: 'total' | 'female' | 'male' | 'by_gender';
+  as_of?: string;
+  scenario?: 'low' | 'medium' | 'high';
+  project_until?: number;
+  profile?: 'modern' | 'early-modern';
 }
 
 export interface GenerateResponse {
   success: boolean;
   message?: string;
+  tree_id?: string;
   tree?: VisualizationData;
   stats?: {
     generation_time: string;
@@ -109,6 +196,7 @@ export interface CountryInfo {
   name: string;
   iso_code: string;
   has_name_data: boolean;
+  regions?: string[];
   population?: number;
   life_expectancy?: number;
 }
@@ -117,3 +205,35 @@ export interface CountriesResponse {
   countries: CountryInfo[];
   count: number;
 }
+
+export interface RegionsResponse {
+  slug: string;
+  regions: string[];
+  count: number;
+}
+
+export interface PersonState {
+  id: string;
+  name: string;
+  gender: 'M' | 'F';
+  alive: boolean;
+  age: number;
+  marital_status: string;
+  country: string;
+  household_id?: string;
+}
+
+export interface Household {
+  id: string;
+  family_id?: string;
+  member_ids: string[];
+}
+
+export interface TreeSnapshot {
+  tree_id: string;
+  date: string;
+  living: number;
+  deceased: number;
+  persons: PersonState[];
+  households: Household[];
+}
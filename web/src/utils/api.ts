@@ -1,4 +1,4 @@
-import { GenerateRequest, GenerateResponse, CountriesResponse } from '../types';
+import { GenerateRequest, GenerateResponse, CountriesResponse, RegionsResponse, TreeSnapshot } from '../types';
 
 const API_BASE = import.meta.env.VITE_API_URL || 'http://localhost:8080';
 
@@ -27,6 +27,11 @@ export async function getCountryStats(slug: string): Promise<unknown> {
   return handleResponse(response);
 }
 
+export async function getCountryRegions(slug: string): Promise<RegionsResponse> {
+  const response = await fetch(`${API_BASE}/api/country/${slug}/regions`);
+  return handleResponse<RegionsResponse>(response);
+}
+
 export async function generateTree(request: GenerateRequest): Promise<GenerateResponse> {
   const response = await fetch(`${API_BASE}/api/generate`, {
     method: 'POST',
@@ -38,6 +43,12 @@ export async function generateTree(request: GenerateRequest): Promise<GenerateRe
   return handleResponse<GenerateResponse>(response);
 }
 
+export async function getTreeSnapshot(treeId: string, date?: string): Promise<TreeSnapshot> {
+  const query = date ? `?date=${encodeURIComponent(date)}` : '';
+  const response = await fetch(`${API_BASE}/api/tree/${treeId}/snapshot${query}`);
+  return handleResponse<TreeSnapshot>(response);
+}
+
 export async function checkHealth(): Promise<{ status: string }> {
   const response = await fetch(`${API_BASE}/api/health`);
   return handleResponse(response);